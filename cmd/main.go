@@ -8,11 +8,15 @@ import (
 	"time"
 
 	"task-be/internal/application/service"
+	"task-be/internal/domain"
 	"task-be/internal/infrastructure/config"
 	"task-be/internal/infrastructure/database"
 	"task-be/internal/infrastructure/logger"
 	"task-be/internal/infrastructure/repository"
+	"task-be/internal/infrastructure/storage"
+	"task-be/internal/infrastructure/token"
 	"task-be/internal/interfaces/http/handler"
+	"task-be/internal/interfaces/http/openapi"
 	"task-be/internal/interfaces/http/router"
 
 	"github.com/joho/godotenv"
@@ -41,12 +45,102 @@ func main() {
 
 	// Initialize database
 	db := database.NewDatabase(ctx, cfg)
+
+	// Run migration subcommands instead of serving
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(ctx, db, os.Args[2:]); err != nil {
+			log.Error("Migration command failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Refuse to serve against an outdated schema
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		log.Error("Failed to load migrations", "error", err)
+		panic("Failed to load migrations")
+	}
+	if err := migrator.Verify(ctx); err != nil {
+		log.Error("Database schema is not up to date, run `migrate up` first", "error", err)
+		panic("Database schema is not up to date")
+	}
+
+	workflow, err := domain.ParseWorkflow(cfg.Workflow.Transitions)
+	if err != nil {
+		log.Error("Invalid task workflow configuration", "error", err)
+		panic("Invalid task workflow configuration")
+	}
+
+	policy := domain.DefaultPolicy()
+	if !cfg.Auth.AllowAnonymousRead {
+		delete(policy, domain.RoleAnonymous)
+	}
+	authorizer := service.NewAuthorizer(policy)
+
+	userRepo := repository.NewUserRepository(db)
 	taskRepo := repository.NewTaskRepository(db)
-	taskService := service.NewTaskService(taskRepo)
-	taskHandler := handler.NewTaskHandler(taskService)
+	taskEventRepo := repository.NewTaskEventRepository(db)
+	taskService := service.NewTaskService(taskRepo, taskEventRepo, userRepo, workflow)
+	taskHandler := handler.NewTaskHandler(taskService, authorizer)
+
+	projectRepo := repository.NewProjectRepository(db)
+	projectService := service.NewProjectService(projectRepo)
+	projectHandler := handler.NewProjectHandler(projectService, taskService, authorizer)
+
+	commentRepo := repository.NewCommentRepository(db)
+	commentService := service.NewCommentService(commentRepo, taskRepo, authorizer)
+	commentHandler := handler.NewCommentHandler(commentService, authorizer)
+
+	blobStore, err := storage.NewBlobStore(cfg.Storage)
+	if err != nil {
+		log.Error("Invalid storage configuration", "error", err)
+		panic("Invalid storage configuration")
+	}
+	attachmentRepo := repository.NewAttachmentRepository(db)
+	attachmentService := service.NewAttachmentService(attachmentRepo, taskRepo, blobStore, cfg.Storage.MaxAttachmentSize, cfg.Storage.AllowedTypes)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentService, authorizer, cfg.Storage.MaxAttachmentSize)
+
+	labelRepo := repository.NewLabelRepository(db)
+	labelService := service.NewLabelService(labelRepo)
+	labelHandler := handler.NewLabelHandler(labelService, authorizer)
+
+	// Permanently delete tasks that outlived the trash retention period,
+	// together with the stored content of their attachments
+	go service.RunTrashPurger(ctx, taskService, attachmentService, cfg.Trash.PurgeInterval, cfg.Trash.Retention)
+
+	userService := service.NewUserService(userRepo)
+	userHandler := handler.NewUserHandler(userService, authorizer)
+
+	// Create the initial admin account on first start
+	if err := userService.EnsureAdmin(ctx, cfg.Auth.Username, cfg.Auth.Password); err != nil {
+		log.Error("Failed to create initial admin user", "error", err)
+		panic("Failed to create initial admin user")
+	}
+
+	tokenIssuer, err := token.NewJWTIssuer(cfg.JWT)
+	if err != nil {
+		log.Error("Invalid JWT configuration", "error", err)
+		panic("Invalid JWT configuration")
+	}
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+	authService := service.NewAuthService(userService, userRepo, refreshTokenRepo, tokenIssuer, cfg.JWT.RefreshTokenTTL)
+	authHandler := handler.NewAuthHandler(authService)
+
+	// Remember responses to requests sent with an Idempotency-Key
+	idempotencyRepo := repository.NewIdempotencyRepository(db)
+	idempotencyService := service.NewIdempotencyService(idempotencyRepo, cfg.Idempotency.TTL, cfg.Idempotency.LockTimeout)
+	go service.RunIdempotencyPurger(ctx, idempotencyService, cfg.Idempotency.PurgeInterval)
+
+	// Serve the OpenAPI document and its Swagger UI
+	docsHandler, err := handler.NewDocsHandler(openapi.Spec())
+	if err != nil {
+		log.Error("Failed to render OpenAPI document", "error", err)
+		panic("Failed to render OpenAPI document")
+	}
 
 	// Initialize router
-	e := router.NewRouter(taskHandler, cfg)
+	e := router.NewRouter(taskHandler, projectHandler, commentHandler, attachmentHandler, labelHandler, userHandler, authHandler, docsHandler, userService, authService, idempotencyService)
 
 	// Start server in a goroutine
 	go func() {
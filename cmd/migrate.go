@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"task-be/internal/infrastructure/database"
+	"task-be/internal/infrastructure/logger"
+
+	"gorm.io/gorm"
+)
+
+const migrateUsage = "usage: migrate up | down [steps] | status | redo"
+
+func runMigrate(ctx context.Context, db *gorm.DB, args []string) error {
+	log := logger.GetLogger()
+
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		log.Info("Migrations applied", "count", applied)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps: %s", args[1])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		log.Info("Migrations reverted", "count", reverted)
+	case "redo":
+		if err := migrator.Redo(ctx); err != nil {
+			return err
+		}
+		log.Info("Latest migration redone")
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printMigrationStatus(statuses)
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	return nil
+}
+
+func printMigrationStatus(statuses []database.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, status := range statuses {
+		state := "pending"
+		appliedAt := "-"
+		if status.Applied {
+			state = "applied"
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		if status.ChecksumMismatch {
+			state = "modified"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	w.Flush()
+}
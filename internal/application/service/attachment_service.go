@@ -0,0 +1,314 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/logger"
+)
+
+// orphanBatchSize bounds how many attachments of purged tasks are removed
+// per query.
+const orphanBatchSize = 100
+
+type AttachmentServiceImpl struct {
+	attachmentRepo domain.AttachmentRepository
+	taskRepo       domain.TaskRepository
+	blobStore      domain.BlobStore
+	maxSize        int64
+	allowedTypes   []string
+}
+
+func NewAttachmentService(attachmentRepo domain.AttachmentRepository, taskRepo domain.TaskRepository, blobStore domain.BlobStore, maxSize int64, allowedTypes []string) domain.AttachmentService {
+	return &AttachmentServiceImpl{
+		attachmentRepo: attachmentRepo,
+		taskRepo:       taskRepo,
+		blobStore:      blobStore,
+		maxSize:        maxSize,
+		allowedTypes:   allowedTypes,
+	}
+}
+
+func (s *AttachmentServiceImpl) UploadAttachment(ctx context.Context, taskID uint, input domain.UploadAttachmentInput) (*domain.Attachment, error) {
+	log := logger.GetLogger()
+	log.Info("Uploading attachment", "task_id", taskID, "filename", input.Filename, "size", input.Size)
+
+	user, ok := domain.UserFromContext(ctx)
+	if !ok {
+		log.Error("Attachment without uploader", "task_id", taskID)
+		return nil, domain.ErrAuthenticationRequired
+	}
+
+	if _, err := s.taskRepo.FindByID(ctx, taskID); err != nil {
+		log.Error("Failed to find task for attachment", "error", err, "task_id", taskID)
+		return nil, err
+	}
+
+	if input.Size > s.maxSize {
+		log.Error("Attachment too large", "task_id", taskID, "size", input.Size, "max_size", s.maxSize)
+		return nil, domain.ErrAttachmentTooLarge
+	}
+
+	content, contentType, err := detectContentType(input.Content, input.ContentType)
+	if err != nil {
+		log.Error("Failed to read attachment", "error", err, "task_id", taskID)
+		return nil, err
+	}
+	if !domain.MatchesContentType(contentType, s.allowedTypes) {
+		log.Error("Attachment type not allowed", "task_id", taskID, "content_type", contentType)
+		return nil, domain.ErrAttachmentTypeNotAllowed
+	}
+
+	key, err := newStorageKey(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The declared size is not trusted: the content is counted and hashed
+	// while it streams to the blob store.
+	hasher := sha256.New()
+	counter := &countingWriter{}
+	limited := io.LimitReader(content, s.maxSize+1)
+	if err := s.blobStore.Put(ctx, key, io.TeeReader(limited, io.MultiWriter(hasher, counter)), input.Size, contentType); err != nil {
+		log.Error("Failed to store attachment", "error", err, "task_id", taskID, "key", key)
+		return nil, err
+	}
+	if counter.n > s.maxSize {
+		log.Error("Attachment too large", "task_id", taskID, "max_size", s.maxSize)
+		s.deleteBlob(ctx, key)
+		return nil, domain.ErrAttachmentTooLarge
+	}
+
+	attachment := &domain.Attachment{
+		TaskID:      taskID,
+		UploadedBy:  user.ID,
+		Filename:    domain.CleanFilename(input.Filename),
+		ContentType: contentType,
+		Size:        counter.n,
+		Checksum:    hex.EncodeToString(hasher.Sum(nil)),
+		StorageKey:  key,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := s.attachmentRepo.Create(ctx, attachment); err != nil {
+		log.Error("Failed to create attachment", "error", err, "task_id", taskID)
+		s.deleteBlob(ctx, key)
+		return nil, err
+	}
+
+	log.Info("Attachment uploaded successfully", "id", attachment.ID, "task_id", taskID, "size", attachment.Size)
+	return attachment, nil
+}
+
+func (s *AttachmentServiceImpl) GetAttachments(ctx context.Context, taskID uint) ([]domain.Attachment, error) {
+	log := logger.GetLogger()
+	log.Info("Getting attachments", "task_id", taskID)
+
+	if _, err := s.taskRepo.FindByID(ctx, taskID); err != nil {
+		log.Error("Failed to find task for attachments", "error", err, "task_id", taskID)
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepo.FindByTaskID(ctx, taskID)
+	if err != nil {
+		log.Error("Failed to get attachments", "error", err, "task_id", taskID)
+		return nil, err
+	}
+
+	log.Info("Attachments retrieved successfully", "task_id", taskID, "count", len(attachments))
+	return attachments, nil
+}
+
+func (s *AttachmentServiceImpl) OpenAttachment(ctx context.Context, taskID, attachmentID uint) (*domain.Attachment, io.ReadCloser, error) {
+	log := logger.GetLogger()
+	log.Info("Opening attachment", "id", attachmentID, "task_id", taskID)
+
+	attachment, err := s.findAttachment(ctx, taskID, attachmentID)
+	if err != nil {
+		log.Error("Failed to find attachment", "error", err, "id", attachmentID, "task_id", taskID)
+		return nil, nil, err
+	}
+
+	content, err := s.blobStore.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		log.Error("Failed to open attachment content", "error", err, "id", attachmentID, "key", attachment.StorageKey)
+		return nil, nil, err
+	}
+
+	return attachment, &checksumReader{
+		ReadCloser: content,
+		hash:       sha256.New(),
+		expected:   attachment.Checksum,
+		id:         attachment.ID,
+	}, nil
+}
+
+func (s *AttachmentServiceImpl) DeleteAttachment(ctx context.Context, taskID, attachmentID uint) error {
+	log := logger.GetLogger()
+	log.Info("Deleting attachment", "id", attachmentID, "task_id", taskID)
+
+	attachment, err := s.findAttachment(ctx, taskID, attachmentID)
+	if err != nil {
+		log.Error("Failed to find attachment for delete", "error", err, "id", attachmentID, "task_id", taskID)
+		return err
+	}
+
+	if err := s.attachmentRepo.Delete(ctx, attachment.ID); err != nil {
+		log.Error("Failed to delete attachment", "error", err, "id", attachmentID)
+		return err
+	}
+	s.deleteBlob(ctx, attachment.StorageKey)
+
+	log.Info("Attachment deleted successfully", "id", attachmentID, "task_id", taskID)
+	return nil
+}
+
+// PurgeOrphanedAttachments removes the content and rows of attachments whose
+// task has been purged. An attachment whose content cannot be deleted is
+// kept and retried on the next run.
+func (s *AttachmentServiceImpl) PurgeOrphanedAttachments(ctx context.Context) (int, error) {
+	log := logger.GetLogger()
+
+	purged := 0
+	for {
+		orphans, err := s.attachmentRepo.FindOrphaned(ctx, orphanBatchSize)
+		if err != nil {
+			log.Error("Failed to find orphaned attachments", "error", err)
+			return purged, err
+		}
+
+		removed := 0
+		for _, attachment := range orphans {
+			if err := s.blobStore.Delete(ctx, attachment.StorageKey); err != nil {
+				log.Error("Failed to delete orphaned attachment content", "error", err, "id", attachment.ID, "key", attachment.StorageKey)
+				continue
+			}
+			if err := s.attachmentRepo.Delete(ctx, attachment.ID); err != nil {
+				log.Error("Failed to delete orphaned attachment", "error", err, "id", attachment.ID)
+				return purged, err
+			}
+			removed++
+		}
+		purged += removed
+
+		if len(orphans) < orphanBatchSize || removed == 0 {
+			break
+		}
+	}
+
+	if purged > 0 {
+		log.Info("Orphaned attachments purged", "count", purged)
+	}
+	return purged, nil
+}
+
+// findAttachment loads an attachment of an active task. Attachments of
+// other tasks are reported as not found.
+func (s *AttachmentServiceImpl) findAttachment(ctx context.Context, taskID, attachmentID uint) (*domain.Attachment, error) {
+	if _, err := s.taskRepo.FindByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+	attachment, err := s.attachmentRepo.FindByID(ctx, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.TaskID != taskID {
+		return nil, domain.NewNotFoundError("attachment not found")
+	}
+	return attachment, nil
+}
+
+func (s *AttachmentServiceImpl) deleteBlob(ctx context.Context, key string) {
+	if err := s.blobStore.Delete(ctx, key); err != nil {
+		log := logger.GetLogger()
+		log.Error("Failed to delete attachment content", "error", err, "key", key)
+	}
+}
+
+// plainTextTypes are text formats that sniffing cannot tell apart from
+// plain text. A declared one of these is kept for content that sniffs as
+// text/plain.
+var plainTextTypes = map[string]bool{
+	"text/csv":         true,
+	"text/markdown":    true,
+	"application/json": true,
+}
+
+// detectContentType returns the media type of an upload without parameters.
+// The type is always sniffed from the first bytes of the content, which are
+// put back in front of the returned reader; the declared type is only kept
+// where it refines what sniffing found, so a client cannot pass off
+// arbitrary content as an allowed type.
+func detectContentType(content io.Reader, declared string) (io.Reader, string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, "", err
+	}
+	head = head[:n]
+	content = io.MultiReader(bytes.NewReader(head), content)
+
+	sniffed, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		sniffed = "application/octet-stream"
+	}
+	// The sniffer reports gzip under its legacy name.
+	if sniffed == "application/x-gzip" {
+		sniffed = "application/gzip"
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(declared); err == nil && sniffed == "text/plain" && plainTextTypes[mediaType] {
+		return content, mediaType, nil
+	}
+	return content, sniffed, nil
+}
+
+func newStorageKey(taskID uint) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("tasks/%d/%s", taskID, hex.EncodeToString(random)), nil
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// checksumReader hashes content as it is read and fails the final read if
+// the content does not match the checksum recorded at upload.
+type checksumReader struct {
+	io.ReadCloser
+	hash     hash.Hash
+	expected string
+	id       uint
+}
+
+func (r *checksumReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.hash.Write(p[:n])
+	if errors.Is(err, io.EOF) {
+		if actual := hex.EncodeToString(r.hash.Sum(nil)); actual != r.expected {
+			log := logger.GetLogger()
+			log.Error("Attachment checksum mismatch", "id", r.id, "expected", r.expected, "actual", actual)
+			return n, errors.New("attachment checksum mismatch")
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"task-be/internal/domain"
+)
+
+type MockAttachmentRepository struct {
+	attachments map[uint]*domain.Attachment
+	taskRepo    *MockTaskRepository
+	nextID      uint
+}
+
+func NewMockAttachmentRepository(taskRepo *MockTaskRepository) *MockAttachmentRepository {
+	return &MockAttachmentRepository{
+		attachments: make(map[uint]*domain.Attachment),
+		taskRepo:    taskRepo,
+		nextID:      1,
+	}
+}
+
+func (m *MockAttachmentRepository) Create(ctx context.Context, attachment *domain.Attachment) error {
+	attachment.ID = m.nextID
+	m.attachments[attachment.ID] = attachment
+	m.nextID++
+	return nil
+}
+
+func (m *MockAttachmentRepository) FindByID(ctx context.Context, id uint) (*domain.Attachment, error) {
+	attachment, exists := m.attachments[id]
+	if !exists {
+		return nil, domain.NewNotFoundError("attachment not found")
+	}
+	return attachment, nil
+}
+
+func (m *MockAttachmentRepository) FindByTaskID(ctx context.Context, taskID uint) ([]domain.Attachment, error) {
+	var attachments []domain.Attachment
+	for id := uint(1); id < m.nextID; id++ {
+		if attachment, exists := m.attachments[id]; exists && attachment.TaskID == taskID {
+			attachments = append(attachments, *attachment)
+		}
+	}
+	return attachments, nil
+}
+
+func (m *MockAttachmentRepository) FindOrphaned(ctx context.Context, limit int) ([]domain.Attachment, error) {
+	var orphans []domain.Attachment
+	for id := uint(1); id < m.nextID && len(orphans) < limit; id++ {
+		attachment, exists := m.attachments[id]
+		if !exists {
+			continue
+		}
+		if _, taskExists := m.taskRepo.tasks[attachment.TaskID]; !taskExists {
+			orphans = append(orphans, *attachment)
+		}
+	}
+	return orphans, nil
+}
+
+func (m *MockAttachmentRepository) Delete(ctx context.Context, id uint) error {
+	if _, exists := m.attachments[id]; !exists {
+		return domain.NewNotFoundError("attachment not found")
+	}
+	delete(m.attachments, id)
+	return nil
+}
+
+type memoryBlobStore struct {
+	blobs map[string][]byte
+}
+
+func newMemoryBlobStore() *memoryBlobStore {
+	return &memoryBlobStore{blobs: make(map[string][]byte)}
+}
+
+func (s *memoryBlobStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.blobs[key] = content
+	return nil
+}
+
+func (s *memoryBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	content, exists := s.blobs[key]
+	if !exists {
+		return nil, errors.New("blob not found")
+	}
+	return io.NopCloser(bytes.NewReader(content)), nil
+}
+
+func (s *memoryBlobStore) Delete(ctx context.Context, key string) error {
+	delete(s.blobs, key)
+	return nil
+}
+
+type attachmentTestEnv struct {
+	service     domain.AttachmentService
+	taskService domain.TaskService
+	taskRepo    *MockTaskRepository
+	blobs       *memoryBlobStore
+	ctx         context.Context
+}
+
+func newAttachmentTestEnv(maxSize int64) *attachmentTestEnv {
+	taskRepo := NewMockTaskRepository()
+	blobs := newMemoryBlobStore()
+	return &attachmentTestEnv{
+		service:     NewAttachmentService(NewMockAttachmentRepository(taskRepo), taskRepo, blobs, maxSize, []string{"image/*", "text/plain"}),
+		taskService: NewTaskService(taskRepo, NewMockTaskEventRepository(taskRepo), NewMockUserRepository(), domain.DefaultWorkflow()),
+		taskRepo:    taskRepo,
+		blobs:       blobs,
+		ctx:         domain.ContextWithUser(context.Background(), &domain.User{ID: 1, Username: "alice", Role: domain.RoleEditor}),
+	}
+}
+
+func TestUploadAttachment(t *testing.T) {
+	env := newAttachmentTestEnv(1024)
+	task, _ := env.taskService.CreateTask(env.ctx, domain.CreateTaskInput{Title: "Test Task"})
+
+	attachment, err := env.service.UploadAttachment(env.ctx, task.ID, domain.UploadAttachmentInput{
+		Filename: "../../logs/app.log",
+		Size:     5,
+		Content:  strings.NewReader("hello"),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if attachment.Filename != "app.log" {
+		t.Errorf("Expected filename app.log, got %s", attachment.Filename)
+	}
+	if attachment.ContentType != "text/plain" {
+		t.Errorf("Expected sniffed content type text/plain, got %s", attachment.ContentType)
+	}
+	if attachment.Size != 5 || attachment.UploadedBy != 1 {
+		t.Errorf("Unexpected attachment %+v", attachment)
+	}
+	if attachment.Checksum != "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824" {
+		t.Errorf("Unexpected checksum %s", attachment.Checksum)
+	}
+
+	_, content, err := env.service.OpenAttachment(env.ctx, task.ID, attachment.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	body, err := io.ReadAll(content)
+	content.Close()
+	if err != nil || string(body) != "hello" {
+		t.Errorf("Expected hello, got %q (%v)", body, err)
+	}
+
+	attachments, _ := env.service.GetAttachments(env.ctx, task.ID)
+	if len(attachments) != 1 {
+		t.Errorf("Expected 1 attachment, got %d", len(attachments))
+	}
+}
+
+func TestUploadAttachmentEnforcesLimits(t *testing.T) {
+	env := newAttachmentTestEnv(8)
+	task, _ := env.taskService.CreateTask(env.ctx, domain.CreateTaskInput{Title: "Test Task"})
+
+	_, err := env.service.UploadAttachment(env.ctx, task.ID, domain.UploadAttachmentInput{
+		Filename: "report.pdf", ContentType: "application/pdf", Size: 5, Content: strings.NewReader("%PDF-"),
+	})
+	if err == nil || err.Error() != "attachment type not allowed" {
+		t.Errorf("Expected attachment type not allowed error, got %v", err)
+	}
+
+	_, err = env.service.UploadAttachment(env.ctx, task.ID, domain.UploadAttachmentInput{
+		Filename: "big.txt", ContentType: "text/plain", Size: 100, Content: strings.NewReader(strings.Repeat("a", 100)),
+	})
+	if err == nil || err.Error() != "attachment too large" {
+		t.Errorf("Expected attachment too large error, got %v", err)
+	}
+
+	// A client understating the size is caught while streaming.
+	_, err = env.service.UploadAttachment(env.ctx, task.ID, domain.UploadAttachmentInput{
+		Filename: "big.txt", ContentType: "text/plain", Size: 1, Content: strings.NewReader(strings.Repeat("a", 100)),
+	})
+	if err == nil || err.Error() != "attachment too large" {
+		t.Errorf("Expected attachment too large error, got %v", err)
+	}
+	if len(env.blobs.blobs) != 0 {
+		t.Errorf("Expected rejected uploads to leave no content, got %d blobs", len(env.blobs.blobs))
+	}
+}
+
+func TestUploadAttachmentSniffsContentType(t *testing.T) {
+	env := newAttachmentTestEnv(1024)
+	task, _ := env.taskService.CreateTask(env.ctx, domain.CreateTaskInput{Title: "Test Task"})
+
+	// The declared type does not get content past the allow-list.
+	_, err := env.service.UploadAttachment(env.ctx, task.ID, domain.UploadAttachmentInput{
+		Filename: "cat.png", ContentType: "image/png", Size: 7, Content: strings.NewReader("\x7fELF\x02\x01\x01"),
+	})
+	if err == nil || err.Error() != "attachment type not allowed" {
+		t.Errorf("Expected attachment type not allowed error, got %v", err)
+	}
+	_, err = env.service.UploadAttachment(env.ctx, task.ID, domain.UploadAttachmentInput{
+		Filename: "notes.txt", ContentType: "text/plain", Size: 14, Content: strings.NewReader("<html><script>"),
+	})
+	if err == nil || err.Error() != "attachment type not allowed" {
+		t.Errorf("Expected attachment type not allowed error, got %v", err)
+	}
+
+	// The sniffed type is stored rather than an inconsistent declared one.
+	attachment, err := env.service.UploadAttachment(env.ctx, task.ID, domain.UploadAttachmentInput{
+		Filename: "photo.png", ContentType: "image/png", Size: 5, Content: strings.NewReader("hello"),
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if attachment.ContentType != "text/plain" {
+		t.Errorf("Expected sniffed content type text/plain, got %s", attachment.ContentType)
+	}
+
+	// A text format that sniffs as plain text keeps its declared type,
+	// which is then checked against the allow-list in its own right.
+	_, err = env.service.UploadAttachment(env.ctx, task.ID, domain.UploadAttachmentInput{
+		Filename: "data.json", ContentType: "application/json; charset=utf-8", Size: 2, Content: strings.NewReader("{}"),
+	})
+	if err == nil || err.Error() != "attachment type not allowed" {
+		t.Errorf("Expected attachment type not allowed error, got %v", err)
+	}
+}
+
+func TestOpenAttachmentDetectsCorruption(t *testing.T) {
+	env := newAttachmentTestEnv(1024)
+	task, _ := env.taskService.CreateTask(env.ctx, domain.CreateTaskInput{Title: "Test Task"})
+	attachment, _ := env.service.UploadAttachment(env.ctx, task.ID, domain.UploadAttachmentInput{
+		Filename: "app.log", ContentType: "text/plain", Size: 5, Content: strings.NewReader("hello"),
+	})
+
+	for key := range env.blobs.blobs {
+		env.blobs.blobs[key] = []byte("jello")
+	}
+
+	_, content, err := env.service.OpenAttachment(env.ctx, task.ID, attachment.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_, err = io.ReadAll(content)
+	if err == nil || err.Error() != "attachment checksum mismatch" {
+		t.Errorf("Expected attachment checksum mismatch error, got %v", err)
+	}
+}
+
+func TestPurgeOrphanedAttachments(t *testing.T) {
+	env := newAttachmentTestEnv(1024)
+	kept, _ := env.taskService.CreateTask(env.ctx, domain.CreateTaskInput{Title: "Kept"})
+	purged, _ := env.taskService.CreateTask(env.ctx, domain.CreateTaskInput{Title: "Purged"})
+	for _, task := range []*domain.Task{kept, purged} {
+		env.service.UploadAttachment(env.ctx, task.ID, domain.UploadAttachmentInput{
+			Filename: "app.log", ContentType: "text/plain", Size: 5, Content: strings.NewReader("hello"),
+		})
+	}
+
+	env.taskService.DeleteTask(env.ctx, purged.ID, nil)
+	env.taskService.PurgeTask(env.ctx, purged.ID, nil)
+
+	count, err := env.service.PurgeOrphanedAttachments(env.ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 orphaned attachment, got %d", count)
+	}
+	if len(env.blobs.blobs) != 1 {
+		t.Errorf("Expected 1 stored blob, got %d", len(env.blobs.blobs))
+	}
+	if attachments, _ := env.service.GetAttachments(env.ctx, kept.ID); len(attachments) != 1 {
+		t.Errorf("Expected attachment of kept task to remain, got %d", len(attachments))
+	}
+}
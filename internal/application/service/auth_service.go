@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/logger"
+)
+
+type AuthServiceImpl struct {
+	userService      domain.UserService
+	userRepo         domain.UserRepository
+	refreshTokenRepo domain.RefreshTokenRepository
+	tokenIssuer      domain.TokenIssuer
+	refreshTokenTTL  time.Duration
+}
+
+func NewAuthService(userService domain.UserService, userRepo domain.UserRepository, refreshTokenRepo domain.RefreshTokenRepository, tokenIssuer domain.TokenIssuer, refreshTokenTTL time.Duration) domain.AuthService {
+	return &AuthServiceImpl{
+		userService:      userService,
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		tokenIssuer:      tokenIssuer,
+		refreshTokenTTL:  refreshTokenTTL,
+	}
+}
+
+func (s *AuthServiceImpl) Login(ctx context.Context, username, password string) (*domain.TokenPair, error) {
+	log := logger.GetLogger()
+	log.Info("Logging in", "username", username)
+
+	user, err := s.userService.Authenticate(ctx, username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	familyID, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, raw, err := s.newRefreshToken(user.ID, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.refreshTokenRepo.Create(ctx, refreshToken); err != nil {
+		log.Error("Failed to store refresh token", "error", err, "user_id", user.ID)
+		return nil, err
+	}
+
+	log.Info("User logged in", "user_id", user.ID, "username", user.Username)
+	return s.issuePair(user, refreshToken, raw)
+}
+
+// Refresh exchanges a refresh token for a new token pair. Each refresh token
+// can be used once; presenting an already rotated token is treated as theft
+// and revokes every token of that login session.
+func (s *AuthServiceImpl) Refresh(ctx context.Context, raw string) (*domain.TokenPair, error) {
+	log := logger.GetLogger()
+
+	current, err := s.refreshTokenRepo.FindByHash(ctx, hashToken(raw))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.NewUnauthenticatedError("invalid refresh token")
+		}
+		log.Error("Failed to look up refresh token", "error", err)
+		return nil, err
+	}
+
+	if current.RevokedAt != nil {
+		log.Warn("Revoked refresh token reused, revoking session", "user_id", current.UserID, "family_id", current.FamilyID)
+		if err := s.refreshTokenRepo.RevokeFamily(ctx, current.FamilyID); err != nil {
+			log.Error("Failed to revoke refresh token family", "error", err, "family_id", current.FamilyID)
+		}
+		return nil, domain.NewUnauthenticatedError("invalid refresh token")
+	}
+	if !current.IsActive(time.Now()) {
+		return nil, domain.NewUnauthenticatedError("invalid refresh token")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, current.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.NewUnauthenticatedError("invalid refresh token")
+		}
+		return nil, err
+	}
+	if user.Disabled {
+		return nil, domain.NewUnauthenticatedError("user is disabled")
+	}
+
+	next, nextRaw, err := s.newRefreshToken(user.ID, current.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.refreshTokenRepo.Rotate(ctx, current, next); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			log.Warn("Concurrent refresh token reuse, revoking session", "user_id", user.ID, "family_id", current.FamilyID)
+			if err := s.refreshTokenRepo.RevokeFamily(ctx, current.FamilyID); err != nil {
+				log.Error("Failed to revoke refresh token family", "error", err, "family_id", current.FamilyID)
+			}
+			return nil, domain.NewUnauthenticatedError("invalid refresh token")
+		}
+		log.Error("Failed to rotate refresh token", "error", err, "user_id", user.ID)
+		return nil, err
+	}
+
+	log.Info("Tokens refreshed", "user_id", user.ID)
+	return s.issuePair(user, next, nextRaw)
+}
+
+func (s *AuthServiceImpl) Logout(ctx context.Context, raw string) error {
+	log := logger.GetLogger()
+
+	current, err := s.refreshTokenRepo.FindByHash(ctx, hashToken(raw))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.NewUnauthenticatedError("invalid refresh token")
+		}
+		return err
+	}
+
+	if err := s.refreshTokenRepo.RevokeFamily(ctx, current.FamilyID); err != nil {
+		log.Error("Failed to revoke refresh tokens", "error", err, "family_id", current.FamilyID)
+		return err
+	}
+
+	log.Info("User logged out", "user_id", current.UserID)
+	return nil
+}
+
+func (s *AuthServiceImpl) AuthenticateAccessToken(ctx context.Context, accessToken string) (*domain.User, error) {
+	claims, err := s.tokenIssuer.ParseAccessToken(accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.NewUnauthenticatedError("invalid access token")
+		}
+		return nil, err
+	}
+	if user.Disabled {
+		return nil, domain.NewUnauthenticatedError("user is disabled")
+	}
+
+	return user, nil
+}
+
+func (s *AuthServiceImpl) newRefreshToken(userID uint, familyID string) (*domain.RefreshToken, string, error) {
+	raw, err := randomToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+	return &domain.RefreshToken{
+		UserID:    userID,
+		TokenHash: hashToken(raw),
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(s.refreshTokenTTL),
+	}, raw, nil
+}
+
+func (s *AuthServiceImpl) issuePair(user *domain.User, refreshToken *domain.RefreshToken, raw string) (*domain.TokenPair, error) {
+	accessToken, expiresAt, err := s.tokenIssuer.IssueAccessToken(user)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.TokenPair{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  expiresAt,
+		RefreshToken:          raw,
+		RefreshTokenExpiresAt: refreshToken.ExpiresAt,
+	}, nil
+}
+
+func randomToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// Only a hash of each refresh token is stored, so a database leak does not
+// hand out usable tokens.
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
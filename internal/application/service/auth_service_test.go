@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"task-be/internal/domain"
+)
+
+type MockRefreshTokenRepository struct {
+	tokens map[uint]*domain.RefreshToken
+	nextID uint
+}
+
+func NewMockRefreshTokenRepository() *MockRefreshTokenRepository {
+	return &MockRefreshTokenRepository{
+		tokens: make(map[uint]*domain.RefreshToken),
+		nextID: 1,
+	}
+}
+
+func (m *MockRefreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	token.ID = m.nextID
+	token.CreatedAt = time.Now()
+	m.tokens[token.ID] = token
+	m.nextID++
+	return nil
+}
+
+func (m *MockRefreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	for _, token := range m.tokens {
+		if token.TokenHash == tokenHash {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, domain.NewNotFoundError("refresh token not found")
+}
+
+func (m *MockRefreshTokenRepository) Rotate(ctx context.Context, current *domain.RefreshToken, next *domain.RefreshToken) error {
+	stored := m.tokens[current.ID]
+	if stored.RevokedAt != nil {
+		return domain.NewConflictError("refresh token already used")
+	}
+	m.Create(ctx, next)
+	now := time.Now()
+	stored.RevokedAt = &now
+	stored.ReplacedByID = &next.ID
+	return nil
+}
+
+func (m *MockRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) error {
+	now := time.Now()
+	for _, token := range m.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+type fakeTokenIssuer struct{}
+
+func (fakeTokenIssuer) IssueAccessToken(user *domain.User) (string, time.Time, error) {
+	return "access-" + strconv.Itoa(int(user.ID)), time.Now().Add(time.Minute), nil
+}
+
+func (fakeTokenIssuer) ParseAccessToken(token string) (*domain.AccessTokenClaims, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(token, "access-"))
+	if err != nil {
+		return nil, domain.NewUnauthenticatedError("invalid access token")
+	}
+	return &domain.AccessTokenClaims{UserID: uint(id)}, nil
+}
+
+func newTestAuthService(t *testing.T) (domain.AuthService, domain.UserService) {
+	t.Helper()
+	userRepo := NewMockUserRepository()
+	userService := NewUserService(userRepo)
+	if _, err := userService.CreateUser(context.Background(), domain.CreateUserInput{Username: "alice", Password: "correct horse"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	authService := NewAuthService(userService, userRepo, NewMockRefreshTokenRepository(), fakeTokenIssuer{}, time.Hour)
+	return authService, userService
+}
+
+func TestLoginIssuesTokens(t *testing.T) {
+	authService, _ := newTestAuthService(t)
+	ctx := context.Background()
+
+	tokens, err := authService.Login(ctx, "alice", "correct horse")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if tokens.AccessToken == "" || tokens.RefreshToken == "" {
+		t.Errorf("Expected access and refresh tokens, got %+v", tokens)
+	}
+
+	user, err := authService.AuthenticateAccessToken(ctx, tokens.AccessToken)
+	if err != nil {
+		t.Fatalf("Expected access token to authenticate, got %v", err)
+	}
+
+	if user.Username != "alice" {
+		t.Errorf("Expected user alice, got %s", user.Username)
+	}
+
+	if _, err := authService.Login(ctx, "alice", "wrong password"); err == nil {
+		t.Error("Expected error for wrong password")
+	}
+}
+
+func TestRefreshRotatesToken(t *testing.T) {
+	authService, _ := newTestAuthService(t)
+	ctx := context.Background()
+
+	first, _ := authService.Login(ctx, "alice", "correct horse")
+
+	second, err := authService.Refresh(ctx, first.RefreshToken)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if second.RefreshToken == first.RefreshToken {
+		t.Error("Expected a new refresh token")
+	}
+
+	if _, err := authService.Refresh(ctx, first.RefreshToken); err == nil {
+		t.Error("Expected reused refresh token to be rejected")
+	}
+
+	if _, err := authService.Refresh(ctx, second.RefreshToken); err == nil {
+		t.Error("Expected reuse to revoke the whole session")
+	}
+}
+
+func TestLogoutRevokesRefreshToken(t *testing.T) {
+	authService, _ := newTestAuthService(t)
+	ctx := context.Background()
+
+	tokens, _ := authService.Login(ctx, "alice", "correct horse")
+	if err := authService.Logout(ctx, tokens.RefreshToken); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := authService.Refresh(ctx, tokens.RefreshToken); err == nil {
+		t.Error("Expected refresh after logout to fail")
+	}
+}
+
+func TestAccessTokenRejectedForDisabledUser(t *testing.T) {
+	authService, userService := newTestAuthService(t)
+	ctx := context.Background()
+
+	tokens, _ := authService.Login(ctx, "alice", "correct horse")
+	user, _ := authService.AuthenticateAccessToken(ctx, tokens.AccessToken)
+	userService.SetUserDisabled(ctx, user.ID, true)
+
+	if _, err := authService.AuthenticateAccessToken(ctx, tokens.AccessToken); err == nil {
+		t.Error("Expected disabled user's access token to be rejected")
+	}
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/logger"
+)
+
+type AuthorizerImpl struct {
+	policy domain.Policy
+}
+
+func NewAuthorizer(policy domain.Policy) domain.Authorizer {
+	return &AuthorizerImpl{policy: policy}
+}
+
+// Can reports whether user holds permission; a nil user is checked against
+// the anonymous entry of the policy.
+func (a *AuthorizerImpl) Can(user *domain.User, permission domain.Permission) bool {
+	role := domain.RoleAnonymous
+	if user != nil {
+		if user.Disabled {
+			return false
+		}
+		role = user.Role
+	}
+
+	for _, granted := range a.policy[role] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *AuthorizerImpl) Authorize(ctx context.Context, permission domain.Permission) error {
+	log := logger.GetLogger()
+
+	user, _ := domain.UserFromContext(ctx)
+
+	if a.Can(user, permission) {
+		return nil
+	}
+
+	if user == nil {
+		log.Info("Authorization failed", "permission", permission, "reason", "anonymous")
+		return domain.ErrAuthenticationRequired
+	}
+
+	log.Info("Authorization failed", "permission", permission, "user_id", user.ID, "role", user.Role)
+	return domain.ErrPermissionDenied
+}
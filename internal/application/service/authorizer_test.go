@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"task-be/internal/domain"
+)
+
+func TestAuthorizerDefaultPolicy(t *testing.T) {
+	authorizer := NewAuthorizer(domain.DefaultPolicy())
+
+	viewer := &domain.User{ID: 1, Username: "viewer", Role: domain.RoleViewer}
+	editor := &domain.User{ID: 2, Username: "editor", Role: domain.RoleEditor}
+	admin := &domain.User{ID: 3, Username: "admin", Role: domain.RoleAdmin}
+
+	tests := []struct {
+		name       string
+		user       *domain.User
+		permission domain.Permission
+		expected   bool
+	}{
+		{"anonymous reads tasks", nil, domain.PermissionTaskRead, true},
+		{"anonymous cannot read history", nil, domain.PermissionTaskHistoryRead, false},
+		{"anonymous cannot create", nil, domain.PermissionTaskCreate, false},
+		{"viewer reads history", viewer, domain.PermissionTaskHistoryRead, true},
+		{"viewer cannot create", viewer, domain.PermissionTaskCreate, false},
+		{"viewer cannot update", viewer, domain.PermissionTaskUpdate, false},
+		{"editor creates", editor, domain.PermissionTaskCreate, true},
+		{"editor updates", editor, domain.PermissionTaskUpdate, true},
+		{"editor cannot delete", editor, domain.PermissionTaskDelete, false},
+		{"editor cannot purge", editor, domain.PermissionTaskPurge, false},
+		{"admin deletes", admin, domain.PermissionTaskDelete, true},
+		{"admin purges", admin, domain.PermissionTaskPurge, true},
+		{"admin manages users", admin, domain.PermissionUserManage, true},
+		{"editor cannot manage users", editor, domain.PermissionUserManage, false},
+		{"viewer cannot comment", viewer, domain.PermissionCommentCreate, false},
+		{"editor comments", editor, domain.PermissionCommentCreate, true},
+		{"editor cannot moderate comments", editor, domain.PermissionCommentModerate, false},
+		{"admin moderates comments", admin, domain.PermissionCommentModerate, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := authorizer.Can(tt.user, tt.permission); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAuthorizerDisabledUser(t *testing.T) {
+	authorizer := NewAuthorizer(domain.DefaultPolicy())
+	user := &domain.User{ID: 1, Username: "admin", Role: domain.RoleAdmin, Disabled: true}
+
+	if authorizer.Can(user, domain.PermissionTaskRead) {
+		t.Error("Expected disabled user to have no permissions")
+	}
+}
+
+func TestAuthorizerAuthorize(t *testing.T) {
+	policy := domain.DefaultPolicy()
+	delete(policy, domain.RoleAnonymous)
+	authorizer := NewAuthorizer(policy)
+
+	err := authorizer.Authorize(context.Background(), domain.PermissionTaskRead)
+	if err == nil || err.Error() != "authentication required" {
+		t.Errorf("Expected authentication required error, got %v", err)
+	}
+
+	ctx := domain.ContextWithUser(context.Background(), &domain.User{ID: 1, Username: "viewer", Role: domain.RoleViewer})
+	if err := authorizer.Authorize(ctx, domain.PermissionTaskRead); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	err = authorizer.Authorize(ctx, domain.PermissionTaskDelete)
+	if err == nil || err.Error() != "permission denied" {
+		t.Errorf("Expected permission denied error, got %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/logger"
+)
+
+type CommentServiceImpl struct {
+	commentRepo domain.CommentRepository
+	taskRepo    domain.TaskRepository
+	authorizer  domain.Authorizer
+}
+
+func NewCommentService(commentRepo domain.CommentRepository, taskRepo domain.TaskRepository, authorizer domain.Authorizer) domain.CommentService {
+	return &CommentServiceImpl{commentRepo: commentRepo, taskRepo: taskRepo, authorizer: authorizer}
+}
+
+func (s *CommentServiceImpl) CreateComment(ctx context.Context, taskID uint, body string) (*domain.Comment, error) {
+	log := logger.GetLogger()
+	log.Info("Creating comment", "task_id", taskID)
+
+	user, ok := domain.UserFromContext(ctx)
+	if !ok {
+		log.Error("Comment without author", "task_id", taskID)
+		return nil, domain.ErrAuthenticationRequired
+	}
+
+	if _, err := s.taskRepo.FindByID(ctx, taskID); err != nil {
+		log.Error("Failed to find task for comment", "error", err, "task_id", taskID)
+		return nil, err
+	}
+
+	comment := &domain.Comment{
+		TaskID:    taskID,
+		AuthorID:  user.ID,
+		Body:      body,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if !comment.IsValid() {
+		log.Error("Invalid comment data", "task_id", taskID, "length", len(body))
+		return nil, domain.NewValidationError("invalid comment data")
+	}
+
+	err := s.commentRepo.Create(ctx, comment)
+	if err != nil {
+		log.Error("Failed to create comment", "error", err, "task_id", taskID)
+		return nil, err
+	}
+
+	log.Info("Comment created successfully", "id", comment.ID, "task_id", taskID)
+	return comment, nil
+}
+
+func (s *CommentServiceImpl) GetComments(ctx context.Context, taskID uint, page, limit int) ([]domain.Comment, int64, error) {
+	log := logger.GetLogger()
+	log.Info("Getting comments", "task_id", taskID, "page", page, "limit", limit)
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	if _, err := s.taskRepo.FindByID(ctx, taskID); err != nil {
+		log.Error("Failed to find task for comments", "error", err, "task_id", taskID)
+		return nil, 0, err
+	}
+
+	comments, total, err := s.commentRepo.FindByTaskID(ctx, taskID, page, limit)
+	if err != nil {
+		log.Error("Failed to get comments", "error", err, "task_id", taskID)
+		return nil, 0, err
+	}
+
+	log.Info("Comments retrieved successfully", "task_id", taskID, "count", len(comments), "total", total)
+	return comments, total, nil
+}
+
+func (s *CommentServiceImpl) UpdateComment(ctx context.Context, taskID, commentID uint, body string) (*domain.Comment, error) {
+	log := logger.GetLogger()
+	log.Info("Updating comment", "id", commentID, "task_id", taskID)
+
+	comment, err := s.findEditableComment(ctx, taskID, commentID)
+	if err != nil {
+		log.Error("Failed to find comment for update", "error", err, "id", commentID, "task_id", taskID)
+		return nil, err
+	}
+
+	if comment.Body == body {
+		return comment, nil
+	}
+
+	now := time.Now()
+	comment.Body = body
+	comment.UpdatedAt = now
+	comment.EditedAt = &now
+
+	if !comment.IsValid() {
+		log.Error("Invalid comment data after update", "id", commentID, "length", len(body))
+		return nil, domain.NewValidationError("invalid comment data")
+	}
+
+	err = s.commentRepo.Update(ctx, comment)
+	if err != nil {
+		log.Error("Failed to update comment", "error", err, "id", commentID)
+		return nil, err
+	}
+
+	log.Info("Comment updated successfully", "id", commentID, "task_id", taskID)
+	return comment, nil
+}
+
+func (s *CommentServiceImpl) DeleteComment(ctx context.Context, taskID, commentID uint) error {
+	log := logger.GetLogger()
+	log.Info("Deleting comment", "id", commentID, "task_id", taskID)
+
+	if _, err := s.findEditableComment(ctx, taskID, commentID); err != nil {
+		log.Error("Failed to find comment for delete", "error", err, "id", commentID, "task_id", taskID)
+		return err
+	}
+
+	err := s.commentRepo.Delete(ctx, commentID)
+	if err != nil {
+		log.Error("Failed to delete comment", "error", err, "id", commentID)
+		return err
+	}
+
+	log.Info("Comment deleted successfully", "id", commentID, "task_id", taskID)
+	return nil
+}
+
+// findEditableComment loads a comment of a task and checks that the caller
+// wrote it or may moderate comments. Comments of other tasks are reported as
+// not found so their IDs cannot be probed through the wrong task.
+func (s *CommentServiceImpl) findEditableComment(ctx context.Context, taskID, commentID uint) (*domain.Comment, error) {
+	user, ok := domain.UserFromContext(ctx)
+	if !ok {
+		return nil, domain.ErrAuthenticationRequired
+	}
+
+	if _, err := s.taskRepo.FindByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.TaskID != taskID {
+		return nil, domain.NewNotFoundError("comment not found")
+	}
+
+	if comment.AuthorID != user.ID && !s.authorizer.Can(user, domain.PermissionCommentModerate) {
+		return nil, domain.ErrPermissionDenied
+	}
+	return comment, nil
+}
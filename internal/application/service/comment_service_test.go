@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"task-be/internal/domain"
+)
+
+type MockCommentRepository struct {
+	comments map[uint]*domain.Comment
+	nextID   uint
+}
+
+func NewMockCommentRepository() *MockCommentRepository {
+	return &MockCommentRepository{
+		comments: make(map[uint]*domain.Comment),
+		nextID:   1,
+	}
+}
+
+func (m *MockCommentRepository) Create(ctx context.Context, comment *domain.Comment) error {
+	comment.ID = m.nextID
+	m.comments[comment.ID] = comment
+	m.nextID++
+	return nil
+}
+
+func (m *MockCommentRepository) FindByID(ctx context.Context, id uint) (*domain.Comment, error) {
+	comment, exists := m.comments[id]
+	if !exists {
+		return nil, domain.NewNotFoundError("comment not found")
+	}
+	return comment, nil
+}
+
+func (m *MockCommentRepository) FindByTaskID(ctx context.Context, taskID uint, page, limit int) ([]domain.Comment, int64, error) {
+	var comments []domain.Comment
+	for id := uint(1); id < m.nextID; id++ {
+		if comment, exists := m.comments[id]; exists && comment.TaskID == taskID {
+			comments = append(comments, *comment)
+		}
+	}
+	return comments, int64(len(comments)), nil
+}
+
+func (m *MockCommentRepository) Update(ctx context.Context, comment *domain.Comment) error {
+	if _, exists := m.comments[comment.ID]; !exists {
+		return domain.NewNotFoundError("comment not found")
+	}
+	m.comments[comment.ID] = comment
+	return nil
+}
+
+func (m *MockCommentRepository) Delete(ctx context.Context, id uint) error {
+	if _, exists := m.comments[id]; !exists {
+		return domain.NewNotFoundError("comment not found")
+	}
+	delete(m.comments, id)
+	return nil
+}
+
+func newCommentTestService() (domain.CommentService, domain.TaskService) {
+	taskRepo := NewMockTaskRepository()
+	taskService := NewTaskService(taskRepo, NewMockTaskEventRepository(taskRepo), NewMockUserRepository(), domain.DefaultWorkflow())
+	commentService := NewCommentService(NewMockCommentRepository(), taskRepo, NewAuthorizer(domain.DefaultPolicy()))
+	return commentService, taskService
+}
+
+func TestCreateComment(t *testing.T) {
+	commentService, taskService := newCommentTestService()
+	alice := &domain.User{ID: 1, Username: "alice", Role: domain.RoleEditor}
+	ctx := domain.ContextWithUser(context.Background(), alice)
+
+	task, _ := taskService.CreateTask(ctx, domain.CreateTaskInput{Title: "Test Task"})
+
+	comment, err := commentService.CreateComment(ctx, task.ID, "Looks **good** to me")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if comment.AuthorID != alice.ID {
+		t.Errorf("Expected author %d, got %d", alice.ID, comment.AuthorID)
+	}
+	if comment.EditedAt != nil {
+		t.Error("Expected new comment not to be edited")
+	}
+
+	comments, total, err := commentService.GetComments(ctx, task.ID, 1, 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total != 1 || len(comments) != 1 {
+		t.Errorf("Expected 1 comment, got %d", total)
+	}
+
+	if _, err := commentService.CreateComment(ctx, task.ID, "   "); err == nil || err.Error() != "invalid comment data" {
+		t.Errorf("Expected invalid comment data error, got %v", err)
+	}
+	if _, err := commentService.CreateComment(ctx, task.ID, strings.Repeat("a", domain.MaxCommentLength+1)); err == nil {
+		t.Error("Expected error for overlong comment")
+	}
+	if _, err := commentService.CreateComment(ctx, 999, "Hello"); err == nil || err.Error() != "task not found" {
+		t.Errorf("Expected task not found error, got %v", err)
+	}
+}
+
+func TestUpdateCommentRestrictedToAuthorOrAdmin(t *testing.T) {
+	commentService, taskService := newCommentTestService()
+	alice := &domain.User{ID: 1, Username: "alice", Role: domain.RoleEditor}
+	bob := &domain.User{ID: 2, Username: "bob", Role: domain.RoleEditor}
+	admin := &domain.User{ID: 3, Username: "admin", Role: domain.RoleAdmin}
+	aliceCtx := domain.ContextWithUser(context.Background(), alice)
+	bobCtx := domain.ContextWithUser(context.Background(), bob)
+	adminCtx := domain.ContextWithUser(context.Background(), admin)
+
+	task, _ := taskService.CreateTask(aliceCtx, domain.CreateTaskInput{Title: "Test Task"})
+	comment, _ := commentService.CreateComment(aliceCtx, task.ID, "First draft")
+
+	if _, err := commentService.UpdateComment(bobCtx, task.ID, comment.ID, "Hijacked"); err == nil || err.Error() != "permission denied" {
+		t.Errorf("Expected permission denied error, got %v", err)
+	}
+	if err := commentService.DeleteComment(bobCtx, task.ID, comment.ID); err == nil || err.Error() != "permission denied" {
+		t.Errorf("Expected permission denied error, got %v", err)
+	}
+
+	updated, err := commentService.UpdateComment(aliceCtx, task.ID, comment.ID, "Second draft")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.Body != "Second draft" || updated.EditedAt == nil {
+		t.Errorf("Expected edited comment, got %+v", updated)
+	}
+
+	other, _ := taskService.CreateTask(aliceCtx, domain.CreateTaskInput{Title: "Other Task"})
+	if _, err := commentService.UpdateComment(aliceCtx, other.ID, comment.ID, "Wrong task"); err == nil || err.Error() != "comment not found" {
+		t.Errorf("Expected comment not found error, got %v", err)
+	}
+
+	if err := commentService.DeleteComment(adminCtx, task.ID, comment.ID); err != nil {
+		t.Fatalf("Expected admin to delete comment, got %v", err)
+	}
+	_, total, _ := commentService.GetComments(aliceCtx, task.ID, 1, 10)
+	if total != 0 {
+		t.Errorf("Expected no comments, got %d", total)
+	}
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/logger"
+)
+
+// RunIdempotencyPurger deletes stored idempotent responses past their TTL,
+// checking every interval until ctx is cancelled.
+func RunIdempotencyPurger(ctx context.Context, idempotencyService domain.IdempotencyService, interval time.Duration) {
+	log := logger.GetLogger()
+	log.Info("Idempotency key purger started", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Idempotency key purger stopped")
+			return
+		case <-ticker.C:
+			if _, err := idempotencyService.PurgeExpiredKeys(ctx); err != nil {
+				log.Error("Failed to purge expired idempotency keys", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/logger"
+)
+
+type IdempotencyServiceImpl struct {
+	repo        domain.IdempotencyRepository
+	ttl         time.Duration
+	lockTimeout time.Duration
+}
+
+// NewIdempotencyService keeps completed responses for ttl. A request still
+// in flight after lockTimeout is presumed lost and no longer holds its key.
+func NewIdempotencyService(repo domain.IdempotencyRepository, ttl, lockTimeout time.Duration) domain.IdempotencyService {
+	return &IdempotencyServiceImpl{repo: repo, ttl: ttl, lockTimeout: lockTimeout}
+}
+
+func (s *IdempotencyServiceImpl) Begin(ctx context.Context, scope, key, fingerprint string) (*domain.IdempotencyRecord, error) {
+	log := logger.GetLogger()
+	log.Info("Reserving idempotency key", "scope", scope, "key", key)
+
+	now := time.Now()
+	record := &domain.IdempotencyRecord{
+		Scope:       scope,
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.ttl),
+	}
+	existing, err := s.repo.Reserve(ctx, record, now.Add(-s.lockTimeout))
+	if err != nil {
+		log.Error("Failed to reserve idempotency key", "error", err, "scope", scope, "key", key)
+		return nil, err
+	}
+	if existing == nil {
+		return nil, nil
+	}
+
+	if existing.Fingerprint != fingerprint {
+		log.Error("Idempotency key reused for a different request", "scope", scope, "key", key)
+		return nil, domain.ErrIdempotencyKeyReused
+	}
+	if !existing.IsComplete() {
+		log.Info("Idempotent request still in progress", "scope", scope, "key", key)
+		return nil, domain.ErrIdempotentRequestInProgress
+	}
+
+	log.Info("Replaying idempotent response", "scope", scope, "key", key, "status", existing.StatusCode)
+	return existing, nil
+}
+
+func (s *IdempotencyServiceImpl) Complete(ctx context.Context, scope, key string, statusCode int, header domain.ResponseHeader, body []byte) error {
+	log := logger.GetLogger()
+
+	err := s.repo.Complete(ctx, &domain.IdempotencyRecord{
+		Scope:      scope,
+		Key:        key,
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       body,
+	})
+	if err != nil {
+		log.Error("Failed to store idempotent response", "error", err, "scope", scope, "key", key)
+		return err
+	}
+	return nil
+}
+
+func (s *IdempotencyServiceImpl) Abandon(ctx context.Context, scope, key string) error {
+	log := logger.GetLogger()
+	log.Info("Releasing idempotency key", "scope", scope, "key", key)
+
+	if err := s.repo.Delete(ctx, scope, key); err != nil {
+		log.Error("Failed to release idempotency key", "error", err, "scope", scope, "key", key)
+		return err
+	}
+	return nil
+}
+
+func (s *IdempotencyServiceImpl) PurgeExpiredKeys(ctx context.Context) (int64, error) {
+	log := logger.GetLogger()
+
+	purged, err := s.repo.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		log.Error("Failed to purge expired idempotency keys", "error", err)
+		return 0, err
+	}
+
+	log.Info("Expired idempotency keys purged", "count", purged)
+	return purged, nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-be/internal/domain"
+)
+
+type MockIdempotencyRepository struct {
+	records map[string]*domain.IdempotencyRecord
+}
+
+func NewMockIdempotencyRepository() *MockIdempotencyRepository {
+	return &MockIdempotencyRepository{records: make(map[string]*domain.IdempotencyRecord)}
+}
+
+func (m *MockIdempotencyRepository) Reserve(ctx context.Context, record *domain.IdempotencyRecord, staleBefore time.Time) (*domain.IdempotencyRecord, error) {
+	existing, exists := m.records[record.Scope+"/"+record.Key]
+	if exists && (existing.ExpiresAt.Before(record.CreatedAt) || (!existing.IsComplete() && existing.CreatedAt.Before(staleBefore))) {
+		exists = false
+	}
+	if exists {
+		copied := *existing
+		return &copied, nil
+	}
+	m.records[record.Scope+"/"+record.Key] = record
+	return nil, nil
+}
+
+func (m *MockIdempotencyRepository) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	if stored, exists := m.records[record.Scope+"/"+record.Key]; exists {
+		stored.StatusCode = record.StatusCode
+		stored.Header = record.Header
+		stored.Body = record.Body
+	}
+	return nil
+}
+
+func (m *MockIdempotencyRepository) Delete(ctx context.Context, scope, key string) error {
+	delete(m.records, scope+"/"+key)
+	return nil
+}
+
+func (m *MockIdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	var count int64
+	for id, record := range m.records {
+		if record.ExpiresAt.Before(now) {
+			delete(m.records, id)
+			count++
+		}
+	}
+	return count, nil
+}
+
+func TestIdempotentRequestIsReplayed(t *testing.T) {
+	ctx := context.Background()
+	service := NewIdempotencyService(NewMockIdempotencyRepository(), time.Hour, time.Minute)
+
+	record, err := service.Begin(ctx, "user:1", "key", "fingerprint")
+	if err != nil || record != nil {
+		t.Fatalf("Expected key to be reserved, got %v, %v", record, err)
+	}
+
+	_, err = service.Begin(ctx, "user:1", "key", "fingerprint")
+	if err == nil || err.Error() != "idempotent request in progress" {
+		t.Errorf("Expected idempotent request in progress error, got %v", err)
+	}
+
+	service.Complete(ctx, "user:1", "key", 201, domain.ResponseHeader{"ETag": `"1"`}, []byte(`{"id":1}`))
+
+	record, err = service.Begin(ctx, "user:1", "key", "fingerprint")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if record == nil || record.StatusCode != 201 || string(record.Body) != `{"id":1}` || record.Header["ETag"] != `"1"` {
+		t.Errorf("Expected stored response to be replayed, got %+v", record)
+	}
+
+	_, err = service.Begin(ctx, "user:1", "key", "other fingerprint")
+	if err == nil || err.Error() != "idempotency key reused" {
+		t.Errorf("Expected idempotency key reused error, got %v", err)
+	}
+
+	// Keys of different callers never collide.
+	record, err = service.Begin(ctx, "user:2", "key", "other fingerprint")
+	if err != nil || record != nil {
+		t.Errorf("Expected key to be reserved for another user, got %v, %v", record, err)
+	}
+}
+
+func TestAbandonedIdempotencyKeyCanBeReused(t *testing.T) {
+	ctx := context.Background()
+	service := NewIdempotencyService(NewMockIdempotencyRepository(), time.Hour, time.Minute)
+
+	service.Begin(ctx, "user:1", "key", "fingerprint")
+	service.Abandon(ctx, "user:1", "key")
+
+	record, err := service.Begin(ctx, "user:1", "key", "fingerprint")
+	if err != nil || record != nil {
+		t.Errorf("Expected key to be reserved again, got %v, %v", record, err)
+	}
+}
+
+func TestStaleIdempotencyReservationIsTakenOver(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockIdempotencyRepository()
+	service := NewIdempotencyService(repo, time.Hour, time.Minute)
+
+	service.Begin(ctx, "user:1", "key", "fingerprint")
+	repo.records["user:1/key"].CreatedAt = time.Now().Add(-2 * time.Minute)
+
+	record, err := service.Begin(ctx, "user:1", "key", "fingerprint")
+	if err != nil || record != nil {
+		t.Errorf("Expected stale reservation to be taken over, got %v, %v", record, err)
+	}
+}
+
+func TestPurgeExpiredIdempotencyKeys(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockIdempotencyRepository()
+	service := NewIdempotencyService(repo, time.Hour, time.Minute)
+
+	service.Begin(ctx, "user:1", "expired", "fingerprint")
+	service.Begin(ctx, "user:1", "kept", "fingerprint")
+	repo.records["user:1/expired"].ExpiresAt = time.Now().Add(-time.Second)
+
+	count, err := service.PurgeExpiredKeys(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 purged key, got %d", count)
+	}
+	if _, exists := repo.records["user:1/kept"]; !exists {
+		t.Errorf("Expected unexpired key to remain")
+	}
+}
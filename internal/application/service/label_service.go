@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/logger"
+)
+
+type LabelServiceImpl struct {
+	labelRepo domain.LabelRepository
+}
+
+func NewLabelService(labelRepo domain.LabelRepository) domain.LabelService {
+	return &LabelServiceImpl{labelRepo: labelRepo}
+}
+
+func (s *LabelServiceImpl) CreateLabel(ctx context.Context, input domain.LabelInput) (*domain.Label, error) {
+	log := logger.GetLogger()
+	log.Info("Creating label", "name", input.Name, "color", input.Color)
+
+	color := input.Color
+	if color == "" {
+		color = domain.DefaultLabelColor
+	}
+
+	label := &domain.Label{
+		Name:      normalizeLabelName(input.Name),
+		Color:     color,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if !label.IsValid() {
+		log.Error("Invalid label data", "name", input.Name, "color", input.Color)
+		return nil, domain.NewValidationError("invalid label data")
+	}
+
+	err := s.labelRepo.Create(ctx, label)
+	if err != nil {
+		log.Error("Failed to create label", "error", err, "name", label.Name)
+		return nil, err
+	}
+
+	log.Info("Label created successfully", "id", label.ID, "name", label.Name)
+	return label, nil
+}
+
+func (s *LabelServiceImpl) GetLabelByID(ctx context.Context, id uint) (*domain.Label, error) {
+	log := logger.GetLogger()
+	log.Info("Getting label by ID", "id", id)
+
+	label, err := s.labelRepo.FindByID(ctx, id)
+	if err != nil {
+		log.Error("Failed to get label by ID", "error", err, "id", id)
+		return nil, err
+	}
+
+	return label, nil
+}
+
+func (s *LabelServiceImpl) GetLabels(ctx context.Context) ([]domain.Label, error) {
+	log := logger.GetLogger()
+	log.Info("Getting labels")
+
+	labels, err := s.labelRepo.FindAll(ctx)
+	if err != nil {
+		log.Error("Failed to get labels", "error", err)
+		return nil, err
+	}
+
+	log.Info("Labels retrieved successfully", "count", len(labels))
+	return labels, nil
+}
+
+func (s *LabelServiceImpl) UpdateLabel(ctx context.Context, id uint, input domain.UpdateLabelInput) (*domain.Label, error) {
+	log := logger.GetLogger()
+	log.Info("Updating label", "id", id, "name", input.Name, "color", input.Color)
+
+	label, err := s.labelRepo.FindByID(ctx, id)
+	if err != nil {
+		log.Error("Failed to find label for update", "error", err, "id", id)
+		return nil, err
+	}
+
+	if input.Name != nil {
+		label.Name = normalizeLabelName(*input.Name)
+	}
+	if input.Color != nil {
+		label.Color = *input.Color
+	}
+	label.UpdatedAt = time.Now()
+
+	if !label.IsValid() {
+		log.Error("Invalid label data after update", "id", id)
+		return nil, domain.NewValidationError("invalid label data")
+	}
+
+	err = s.labelRepo.Update(ctx, label)
+	if err != nil {
+		log.Error("Failed to update label", "error", err, "id", id)
+		return nil, err
+	}
+
+	log.Info("Label updated successfully", "id", id, "name", label.Name)
+	return label, nil
+}
+
+func (s *LabelServiceImpl) DeleteLabel(ctx context.Context, id uint) error {
+	log := logger.GetLogger()
+	log.Info("Deleting label", "id", id)
+
+	err := s.labelRepo.Delete(ctx, id)
+	if err != nil {
+		log.Error("Failed to delete label", "error", err, "id", id)
+		return err
+	}
+
+	log.Info("Label deleted successfully", "id", id)
+	return nil
+}
+
+// normalizeLabelName lowercases label names so "Bug" and "bug" are the same
+// label in filters.
+func normalizeLabelName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// normalizeLabelNames normalises and de-duplicates a label filter so that
+// "all" matching compares against the number of distinct labels.
+func normalizeLabelNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = normalizeLabelName(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+	return normalized
+}
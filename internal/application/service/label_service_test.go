@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"task-be/internal/domain"
+)
+
+type MockLabelRepository struct {
+	labels map[uint]*domain.Label
+	nextID uint
+}
+
+func NewMockLabelRepository() *MockLabelRepository {
+	return &MockLabelRepository{
+		labels: make(map[uint]*domain.Label),
+		nextID: 1,
+	}
+}
+
+func (m *MockLabelRepository) Create(ctx context.Context, label *domain.Label) error {
+	for _, existing := range m.labels {
+		if existing.Name == label.Name {
+			return domain.NewConflictError("label already exists")
+		}
+	}
+	label.ID = m.nextID
+	label.CreatedAt = time.Now()
+	label.UpdatedAt = time.Now()
+	m.labels[label.ID] = label
+	m.nextID++
+	return nil
+}
+
+func (m *MockLabelRepository) FindByID(ctx context.Context, id uint) (*domain.Label, error) {
+	label, exists := m.labels[id]
+	if !exists {
+		return nil, domain.NewNotFoundError("label not found")
+	}
+	return label, nil
+}
+
+func (m *MockLabelRepository) FindAll(ctx context.Context) ([]domain.Label, error) {
+	var labels []domain.Label
+	for _, label := range m.labels {
+		labels = append(labels, *label)
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+	return labels, nil
+}
+
+func (m *MockLabelRepository) Update(ctx context.Context, label *domain.Label) error {
+	for _, existing := range m.labels {
+		if existing.Name == label.Name && existing.ID != label.ID {
+			return domain.NewConflictError("label already exists")
+		}
+	}
+	m.labels[label.ID] = label
+	return nil
+}
+
+func (m *MockLabelRepository) Delete(ctx context.Context, id uint) error {
+	if _, exists := m.labels[id]; !exists {
+		return domain.NewNotFoundError("label not found")
+	}
+	delete(m.labels, id)
+	return nil
+}
+
+func TestCreateLabel(t *testing.T) {
+	repo := NewMockLabelRepository()
+	service := NewLabelService(repo)
+	ctx := context.Background()
+
+	label, err := service.CreateLabel(ctx, domain.LabelInput{Name: " Bug "})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if label.Name != "bug" {
+		t.Errorf("Expected normalised name 'bug', got %s", label.Name)
+	}
+
+	if label.Color != domain.DefaultLabelColor {
+		t.Errorf("Expected default color %s, got %s", domain.DefaultLabelColor, label.Color)
+	}
+
+	if _, err := service.CreateLabel(ctx, domain.LabelInput{Name: "BUG"}); err == nil || err.Error() != "label already exists" {
+		t.Errorf("Expected label already exists error, got %v", err)
+	}
+}
+
+func TestCreateLabelWithInvalidColor(t *testing.T) {
+	repo := NewMockLabelRepository()
+	service := NewLabelService(repo)
+	ctx := context.Background()
+
+	_, err := service.CreateLabel(ctx, domain.LabelInput{Name: "infra", Color: "blue"})
+	if err == nil {
+		t.Error("Expected error for invalid color")
+	}
+}
+
+func TestUpdateLabel(t *testing.T) {
+	repo := NewMockLabelRepository()
+	service := NewLabelService(repo)
+	ctx := context.Background()
+
+	label, _ := service.CreateLabel(ctx, domain.LabelInput{Name: "frontend"})
+
+	color := "#1E90FF"
+	updated, err := service.UpdateLabel(ctx, label.ID, domain.UpdateLabelInput{Color: &color})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if updated.Color != color || updated.Name != "frontend" {
+		t.Errorf("Expected frontend/%s, got %s/%s", color, updated.Name, updated.Color)
+	}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/logger"
+)
+
+type ProjectServiceImpl struct {
+	projectRepo domain.ProjectRepository
+}
+
+func NewProjectService(projectRepo domain.ProjectRepository) domain.ProjectService {
+	return &ProjectServiceImpl{projectRepo: projectRepo}
+}
+
+func (s *ProjectServiceImpl) CreateProject(ctx context.Context, input domain.ProjectInput) (*domain.Project, error) {
+	log := logger.GetLogger()
+	log.Info("Creating project", "key", input.Key, "name", input.Name)
+
+	project := &domain.Project{
+		Key:         strings.ToUpper(strings.TrimSpace(input.Key)),
+		Name:        strings.TrimSpace(input.Name),
+		Description: input.Description,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if !project.IsValid() {
+		log.Error("Invalid project data", "key", input.Key, "name", input.Name)
+		return nil, domain.NewValidationError("invalid project data")
+	}
+
+	err := s.projectRepo.Create(ctx, project)
+	if err != nil {
+		log.Error("Failed to create project", "error", err, "key", project.Key)
+		return nil, err
+	}
+
+	log.Info("Project created successfully", "id", project.ID, "key", project.Key)
+	return project, nil
+}
+
+func (s *ProjectServiceImpl) GetProjectByID(ctx context.Context, id uint) (*domain.Project, error) {
+	log := logger.GetLogger()
+	log.Info("Getting project by ID", "id", id)
+
+	project, err := s.projectRepo.FindByID(ctx, id)
+	if err != nil {
+		log.Error("Failed to get project by ID", "error", err, "id", id)
+		return nil, err
+	}
+
+	return project, nil
+}
+
+func (s *ProjectServiceImpl) GetProjects(ctx context.Context, page, limit int, includeArchived bool) ([]domain.Project, int64, error) {
+	log := logger.GetLogger()
+	log.Info("Getting projects", "page", page, "limit", limit, "include_archived", includeArchived)
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	projects, total, err := s.projectRepo.FindAll(ctx, page, limit, includeArchived)
+	if err != nil {
+		log.Error("Failed to get projects", "error", err, "page", page, "limit", limit)
+		return nil, 0, err
+	}
+
+	log.Info("Projects retrieved successfully", "count", len(projects), "total", total)
+	return projects, total, nil
+}
+
+func (s *ProjectServiceImpl) UpdateProject(ctx context.Context, id uint, input domain.UpdateProjectInput) (*domain.Project, error) {
+	log := logger.GetLogger()
+	log.Info("Updating project", "id", id, "name", input.Name, "archived", input.Archived)
+
+	project, err := s.projectRepo.FindByID(ctx, id)
+	if err != nil {
+		log.Error("Failed to find project for update", "error", err, "id", id)
+		return nil, err
+	}
+
+	if input.Name != nil {
+		project.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Description != nil {
+		project.Description = *input.Description
+	}
+	if input.Archived != nil {
+		project.Archived = *input.Archived
+	}
+	project.UpdatedAt = time.Now()
+
+	if !project.IsValid() {
+		log.Error("Invalid project data after update", "id", id)
+		return nil, domain.NewValidationError("invalid project data")
+	}
+
+	err = s.projectRepo.Update(ctx, project)
+	if err != nil {
+		log.Error("Failed to update project", "error", err, "id", id)
+		return nil, err
+	}
+
+	log.Info("Project updated successfully", "id", id, "key", project.Key)
+	return project, nil
+}
+
+func (s *ProjectServiceImpl) DeleteProject(ctx context.Context, id uint) error {
+	log := logger.GetLogger()
+	log.Info("Deleting project", "id", id)
+
+	err := s.projectRepo.Delete(ctx, id)
+	if err != nil {
+		log.Error("Failed to delete project", "error", err, "id", id)
+		return err
+	}
+
+	log.Info("Project deleted successfully", "id", id)
+	return nil
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-be/internal/domain"
+)
+
+type MockProjectRepository struct {
+	projects map[uint]*domain.Project
+	nextID   uint
+}
+
+func NewMockProjectRepository() *MockProjectRepository {
+	return &MockProjectRepository{
+		projects: make(map[uint]*domain.Project),
+		nextID:   1,
+	}
+}
+
+func (m *MockProjectRepository) Create(ctx context.Context, project *domain.Project) error {
+	for _, existing := range m.projects {
+		if existing.Key == project.Key {
+			return domain.NewConflictError("project key already exists")
+		}
+	}
+	project.ID = m.nextID
+	project.CreatedAt = time.Now()
+	project.UpdatedAt = time.Now()
+	m.projects[project.ID] = project
+	m.nextID++
+	return nil
+}
+
+func (m *MockProjectRepository) FindByID(ctx context.Context, id uint) (*domain.Project, error) {
+	project, exists := m.projects[id]
+	if !exists {
+		return nil, domain.NewNotFoundError("project not found")
+	}
+	return project, nil
+}
+
+func (m *MockProjectRepository) FindAll(ctx context.Context, page, limit int, includeArchived bool) ([]domain.Project, int64, error) {
+	var projects []domain.Project
+	for _, project := range m.projects {
+		if project.Archived && !includeArchived {
+			continue
+		}
+		projects = append(projects, *project)
+	}
+	return projects, int64(len(projects)), nil
+}
+
+func (m *MockProjectRepository) Update(ctx context.Context, project *domain.Project) error {
+	if _, exists := m.projects[project.ID]; !exists {
+		return domain.NewNotFoundError("project not found")
+	}
+	m.projects[project.ID] = project
+	return nil
+}
+
+func (m *MockProjectRepository) Delete(ctx context.Context, id uint) error {
+	if _, exists := m.projects[id]; !exists {
+		return domain.NewNotFoundError("project not found")
+	}
+	delete(m.projects, id)
+	return nil
+}
+
+func TestCreateProject(t *testing.T) {
+	repo := NewMockProjectRepository()
+	service := NewProjectService(repo)
+	ctx := context.Background()
+
+	project, err := service.CreateProject(ctx, domain.ProjectInput{Key: "ops", Name: "Operations"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if project.Key != "OPS" {
+		t.Errorf("Expected key 'OPS', got %s", project.Key)
+	}
+
+	if _, err := service.CreateProject(ctx, domain.ProjectInput{Key: "OPS", Name: "Other"}); err == nil {
+		t.Error("Expected error for duplicate key")
+	}
+}
+
+func TestCreateProjectWithInvalidKey(t *testing.T) {
+	repo := NewMockProjectRepository()
+	service := NewProjectService(repo)
+	ctx := context.Background()
+
+	for _, key := range []string{"", "O", "1OPS", "OPS-1", "TOOLONGPROJECT"} {
+		if _, err := service.CreateProject(ctx, domain.ProjectInput{Key: key, Name: "Operations"}); err == nil {
+			t.Errorf("Expected error for key %q", key)
+		}
+	}
+}
+
+func TestArchiveProject(t *testing.T) {
+	repo := NewMockProjectRepository()
+	service := NewProjectService(repo)
+	ctx := context.Background()
+
+	project, _ := service.CreateProject(ctx, domain.ProjectInput{Key: "OPS", Name: "Operations"})
+
+	archived := true
+	updated, err := service.UpdateProject(ctx, project.ID, domain.UpdateProjectInput{Archived: &archived})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !updated.Archived {
+		t.Error("Expected project to be archived")
+	}
+
+	projects, total, _ := service.GetProjects(ctx, 1, 10, false)
+	if total != 0 || len(projects) != 0 {
+		t.Errorf("Expected archived project to be hidden, got %d", total)
+	}
+
+	_, total, _ = service.GetProjects(ctx, 1, 10, true)
+	if total != 1 {
+		t.Errorf("Expected archived project to be listed, got %d", total)
+	}
+}
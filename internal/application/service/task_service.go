@@ -3,44 +3,89 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"task-be/internal/domain"
 	"task-be/internal/infrastructure/logger"
 )
 
 type TaskServiceImpl struct {
-	taskRepo domain.TaskRepository
+	taskRepo  domain.TaskRepository
+	eventRepo domain.TaskEventRepository
+	userRepo  domain.UserRepository
+	workflow  *domain.Workflow
 }
 
-func NewTaskService(taskRepo domain.TaskRepository) domain.TaskService {
-	return &TaskServiceImpl{taskRepo: taskRepo}
+func NewTaskService(taskRepo domain.TaskRepository, eventRepo domain.TaskEventRepository, userRepo domain.UserRepository, workflow *domain.Workflow) domain.TaskService {
+	return &TaskServiceImpl{taskRepo: taskRepo, eventRepo: eventRepo, userRepo: userRepo, workflow: workflow}
 }
 
-func (s *TaskServiceImpl) CreateTask(ctx context.Context, title, description string) (*domain.Task, error) {
+func (s *TaskServiceImpl) CreateTask(ctx context.Context, input domain.CreateTaskInput) (*domain.Task, error) {
 	log := logger.GetLogger()
-	log.Info("Creating task", "title", title, "description", description)
+	log.Info("Creating task", "title", input.Title, "description", input.Description, "priority", input.Priority)
+
+	task, err := s.newTask(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.taskRepo.Create(ctx, task)
+	if err != nil {
+		log.Error("Failed to create task", "error", err, "title", input.Title)
+		return nil, err
+	}
+
+	markOverdue(task)
+
+	log.Info("Task created successfully", "id", task.ID, "title", task.Title)
+	return task, nil
+}
+
+// newTask builds and validates the task described by input.
+func (s *TaskServiceImpl) newTask(ctx context.Context, input domain.CreateTaskInput) (*domain.Task, error) {
+	log := logger.GetLogger()
+
+	priority := input.Priority
+	if priority == "" {
+		priority = domain.PriorityMedium
+	}
 
 	task := &domain.Task{
-		Title:       title,
-		Description: description,
+		Title:       domain.NormalizeTaskTitle(input.Title),
+		Description: input.Description,
 		Status:      domain.StatusToDo,
+		Priority:    priority,
+		StartAt:     input.StartAt,
+		DueAt:       input.DueAt,
+		ProjectID:   input.ProjectID,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
-
-	if !task.IsValid() {
-		log.Error("Invalid task data", "title", title)
-		return nil, errors.New("invalid task data")
+	if user, ok := domain.UserFromContext(ctx); ok {
+		task.CreatedBy = &user.ID
+	}
+	if input.ParentID != nil {
+		if _, err := s.taskRepo.FindByID(ctx, *input.ParentID); err != nil {
+			log.Error("Failed to find parent task", "error", err, "parent_id", *input.ParentID)
+			if errors.Is(err, domain.ErrNotFound) {
+				return nil, domain.NewUnprocessableError("parent task not found")
+			}
+			return nil, err
+		}
+		task.ParentID = input.ParentID
 	}
 
-	err := s.taskRepo.Create(ctx, task)
-	if err != nil {
-		log.Error("Failed to create task", "error", err, "title", title)
-		return nil, err
+	if !task.HasValidSchedule() {
+		log.Error("Invalid task schedule", "title", input.Title, "start_at", input.StartAt, "due_at", input.DueAt)
+		return nil, domain.NewValidationError("start date must be before due date", domain.FieldError{Field: "due_at", Code: "invalid_schedule", Message: "must be after start_at"})
 	}
 
-	log.Info("Task created successfully", "id", task.ID, "title", task.Title)
+	if !task.IsValid() {
+		log.Error("Invalid task data", "title", input.Title, "priority", input.Priority)
+		return nil, domain.NewValidationError("invalid task data")
+	}
 	return task, nil
 }
 
@@ -54,13 +99,44 @@ func (s *TaskServiceImpl) GetTaskByID(ctx context.Context, id uint) (*domain.Tas
 		return nil, err
 	}
 
+	markOverdue(task)
+
 	log.Info("Task retrieved successfully", "id", id, "title", task.Title)
 	return task, nil
 }
 
-func (s *TaskServiceImpl) GetTasks(ctx context.Context, page, limit int, status *domain.TaskStatus) ([]domain.Task, int64, error) {
+func (s *TaskServiceImpl) GetTaskByKey(ctx context.Context, key string) (*domain.Task, error) {
 	log := logger.GetLogger()
-	log.Info("Getting tasks", "page", page, "limit", limit, "status", status)
+	log.Info("Getting task by key", "key", key)
+
+	task, err := s.taskRepo.FindByKey(ctx, key)
+	if err != nil {
+		log.Error("Failed to get task by key", "error", err, "key", key)
+		return nil, err
+	}
+
+	markOverdue(task)
+
+	log.Info("Task retrieved successfully", "id", task.ID, "key", key)
+	return task, nil
+}
+
+func (s *TaskServiceImpl) GetTasks(ctx context.Context, page, limit int, filter domain.TaskFilter) ([]domain.Task, int64, error) {
+	log := logger.GetLogger()
+	log.Info("Getting tasks", "page", page, "limit", limit, "statuses", filter.Statuses, "priority", filter.Priority,
+		"sort", domain.FormatTaskSort(filter.Sort), "title_contains", filter.TitleContains,
+		"created_after", filter.CreatedAfter, "created_before", filter.CreatedBefore,
+		"updated_after", filter.UpdatedAfter, "updated_before", filter.UpdatedBefore,
+		"due_before", filter.DueBefore, "due_after", filter.DueAfter, "overdue", filter.Overdue, "deleted", filter.Deleted,
+		"assignee_id", filter.AssigneeID, "unassigned", filter.Unassigned, "created_by", filter.CreatedBy,
+		"labels", filter.Labels, "label_mode", filter.LabelMode, "parent_id", filter.ParentID, "top_level", filter.TopLevel,
+		"project_id", filter.ProjectID)
+
+	filter, err := normalizeTaskFilter(filter)
+	if err != nil {
+		log.Error("Invalid label match mode", "label_mode", filter.LabelMode)
+		return nil, 0, err
+	}
 
 	if page < 1 {
 		page = 1
@@ -69,62 +145,391 @@ func (s *TaskServiceImpl) GetTasks(ctx context.Context, page, limit int, status
 		limit = 10
 	}
 
-	tasks, total, err := s.taskRepo.FindAll(ctx, page, limit, status)
+	tasks, total, err := s.taskRepo.FindAll(ctx, page, limit, filter)
 	if err != nil {
 		log.Error("Failed to get tasks", "error", err, "page", page, "limit", limit)
 		return nil, 0, err
 	}
 
+	for i := range tasks {
+		markOverdue(&tasks[i])
+	}
+
 	log.Info("Tasks retrieved successfully", "count", len(tasks), "total", total)
 	return tasks, total, nil
 }
 
-func (s *TaskServiceImpl) UpdateTask(ctx context.Context, id uint, title, description *string, status *domain.TaskStatus) (*domain.Task, error) {
+func (s *TaskServiceImpl) GetTaskPage(ctx context.Context, filter domain.TaskFilter, request domain.TaskPageRequest) (*domain.TaskPage, error) {
 	log := logger.GetLogger()
-	log.Info("Updating task", "id", id, "title", title, "status", status)
+	log.Info("Getting task page", "limit", request.Limit, "after_cursor", request.Cursor != nil,
+		"include_total", request.IncludeTotal, "statuses", filter.Statuses, "priority", filter.Priority,
+		"sort", domain.FormatTaskSort(filter.Sort), "deleted", filter.Deleted, "project_id", filter.ProjectID, "parent_id", filter.ParentID)
+
+	filter, err := normalizeTaskFilter(filter)
+	if err != nil {
+		log.Error("Invalid label match mode", "label_mode", filter.LabelMode)
+		return nil, err
+	}
+
+	if request.Limit < 1 {
+		request.Limit = 10
+	}
+	if request.Cursor != nil {
+		if _, err := request.Cursor.SortValues(filter.Sort); err != nil {
+			log.Error("Cursor does not match the listing order", "sort", domain.FormatTaskSort(filter.Sort), "cursor_sort", request.Cursor.Sort)
+			return nil, err
+		}
+	}
+
+	page, err := s.taskRepo.FindPage(ctx, filter, request)
+	if err != nil {
+		log.Error("Failed to get task page", "error", err, "limit", request.Limit)
+		return nil, err
+	}
+
+	for i := range page.Tasks {
+		markOverdue(&page.Tasks[i])
+	}
+
+	log.Info("Task page retrieved successfully", "count", len(page.Tasks), "has_next", page.Next != nil)
+	return page, nil
+}
+
+func (s *TaskServiceImpl) SearchTasks(ctx context.Context, query string, page, limit int, filter domain.TaskFilter) ([]domain.TaskSearchResult, int64, error) {
+	log := logger.GetLogger()
+	log.Info("Searching tasks", "query", query, "page", page, "limit", limit, "statuses", filter.Statuses,
+		"priority", filter.Priority, "deleted", filter.Deleted, "project_id", filter.ProjectID)
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		log.Error("Empty search query")
+		return nil, 0, domain.NewValidationError("search query is required")
+	}
+	if utf8.RuneCountInString(query) > domain.MaxSearchQueryLength {
+		log.Error("Search query too long", "length", utf8.RuneCountInString(query))
+		return nil, 0, domain.NewValidationError("search query is too long")
+	}
+
+	filter, err := normalizeTaskFilter(filter)
+	if err != nil {
+		log.Error("Invalid label match mode", "label_mode", filter.LabelMode)
+		return nil, 0, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	results, total, err := s.taskRepo.Search(ctx, query, page, limit, filter)
+	if err != nil {
+		log.Error("Failed to search tasks", "error", err, "query", query)
+		return nil, 0, err
+	}
+
+	for i := range results {
+		markOverdue(&results[i].Task)
+	}
+
+	log.Info("Tasks searched successfully", "query", query, "count", len(results), "total", total)
+	return results, total, nil
+}
+
+func (s *TaskServiceImpl) UpdateTask(ctx context.Context, id uint, input domain.UpdateTaskInput) (*domain.Task, error) {
+	log := logger.GetLogger()
+	log.Info("Updating task", "id", id, "title", input.Title, "status", input.Status, "priority", input.Priority,
+		"version", input.Version)
 
 	task, err := s.taskRepo.FindByID(ctx, id)
 	if err != nil {
 		log.Error("Failed to find task for update", "error", err, "id", id)
 		return nil, err
 	}
+	if err := s.applyTaskUpdate(ctx, task, input); err != nil {
+		return nil, err
+	}
 
-	if title != nil {
-		task.Title = *title
+	err = s.taskRepo.Update(ctx, task)
+	if err != nil {
+		log.Error("Failed to update task", "error", err, "id", id)
+		return nil, err
 	}
-	if description != nil {
-		task.Description = *description
+
+	markOverdue(task)
+
+	log.Info("Task updated successfully", "id", id, "title", task.Title, "status", task.Status, "priority", task.Priority)
+	return task, nil
+}
+
+// applyTaskUpdate applies input to task, enforcing the workflow and the
+// schedule rules. A new parent is checked by the repository when the task
+// is saved, under the lock that serialises hierarchy writes.
+func (s *TaskServiceImpl) applyTaskUpdate(ctx context.Context, task *domain.Task, input domain.UpdateTaskInput) error {
+	log := logger.GetLogger()
+	id := task.ID
+
+	if input.Version != nil && task.Version != *input.Version {
+		log.Error("Task version mismatch", "id", id, "version", task.Version, "expected", *input.Version)
+		return domain.ErrVersionMismatch
 	}
-	if status != nil {
-		if !task.IsValidStatus(*status) {
-			log.Error("Invalid status provided", "status", *status, "id", id)
-			return nil, errors.New("invalid status")
+
+	if input.Title != nil {
+		task.Title = domain.NormalizeTaskTitle(*input.Title)
+	}
+	if input.Description != nil {
+		task.Description = *input.Description
+	}
+	if input.Status != nil {
+		if !task.IsValidStatus(*input.Status) {
+			log.Error("Invalid status provided", "status", *input.Status, "id", id)
+			return domain.NewValidationError("invalid status", domain.FieldError{Field: "status", Code: "invalid_status", Message: "is not a known status"})
+		}
+		if err := s.workflow.Transition(task.Status, *input.Status); err != nil {
+			log.Error("Status transition not allowed", "from", task.Status, "to", *input.Status, "id", id)
+			return err
+		}
+		if *input.Status == domain.StatusDone && task.Status != domain.StatusDone &&
+			task.Progress.OpenChildren() > 0 && !input.Force {
+			log.Error("Task has open subtasks", "id", id, "open", task.Progress.OpenChildren())
+			return domain.NewConflictError("task has open subtasks")
 		}
-		task.Status = *status
+		if *input.Status == domain.StatusInProgress && task.Status != domain.StatusInProgress &&
+			task.IsBlocked && !input.Force {
+			log.Error("Task is blocked", "id", id)
+			return domain.NewConflictError("task is blocked by open dependencies")
+		}
+		task.Status = *input.Status
+	}
+	if input.Priority != nil {
+		if !task.IsValidPriority(*input.Priority) {
+			log.Error("Invalid priority provided", "priority", *input.Priority, "id", id)
+			return domain.NewValidationError("invalid priority", domain.FieldError{Field: "priority", Code: "invalid_priority", Message: "is not a known priority"})
+		}
+		task.Priority = *input.Priority
+	}
+	if input.ClearParent {
+		task.ParentID = nil
+	} else if input.ParentID != nil {
+		task.ParentID = input.ParentID
+	}
+	if input.ClearStartAt {
+		task.StartAt = nil
+	} else if input.StartAt != nil {
+		task.StartAt = input.StartAt
+	}
+	if input.ClearDueAt {
+		task.DueAt = nil
+	} else if input.DueAt != nil {
+		task.DueAt = input.DueAt
 	}
 
 	task.UpdatedAt = time.Now()
 
+	if !task.HasValidSchedule() {
+		log.Error("Invalid task schedule after update", "id", id, "start_at", task.StartAt, "due_at", task.DueAt)
+		return domain.NewValidationError("start date must be before due date", domain.FieldError{Field: "due_at", Code: "invalid_schedule", Message: "must be after start_at"})
+	}
+
 	if !task.IsValid() {
 		log.Error("Invalid task data after update", "id", id)
-		return nil, errors.New("invalid task data")
+		return domain.NewValidationError("invalid task data")
 	}
 
+	return nil
+}
+
+func (s *TaskServiceImpl) AssignTask(ctx context.Context, id uint, assigneeID uint) (*domain.Task, error) {
+	log := logger.GetLogger()
+	log.Info("Assigning task", "id", id, "assignee_id", assigneeID)
+
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		log.Error("Failed to find task for assignment", "error", err, "id", id)
+		return nil, err
+	}
+
+	assignee, err := s.userRepo.FindByID(ctx, assigneeID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			log.Error("Assignee does not exist", "id", id, "assignee_id", assigneeID)
+			return nil, domain.NewUnprocessableError("assignee not found")
+		}
+		log.Error("Failed to find assignee", "error", err, "id", id, "assignee_id", assigneeID)
+		return nil, err
+	}
+	if assignee.Disabled {
+		log.Error("Assignee is disabled", "id", id, "assignee_id", assigneeID)
+		return nil, domain.NewUnprocessableError("assignee is disabled")
+	}
+
+	task.AssigneeID = &assignee.ID
+	task.UpdatedAt = time.Now()
+
 	err = s.taskRepo.Update(ctx, task)
 	if err != nil {
-		log.Error("Failed to update task", "error", err, "id", id)
+		log.Error("Failed to assign task", "error", err, "id", id)
+		return nil, err
+	}
+
+	markOverdue(task)
+
+	log.Info("Task assigned successfully", "id", id, "assignee_id", assigneeID)
+	return task, nil
+}
+
+func (s *TaskServiceImpl) UnassignTask(ctx context.Context, id uint) (*domain.Task, error) {
+	log := logger.GetLogger()
+	log.Info("Unassigning task", "id", id)
+
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		log.Error("Failed to find task for unassignment", "error", err, "id", id)
+		return nil, err
+	}
+
+	task.AssigneeID = nil
+	task.UpdatedAt = time.Now()
+
+	err = s.taskRepo.Update(ctx, task)
+	if err != nil {
+		log.Error("Failed to unassign task", "error", err, "id", id)
+		return nil, err
+	}
+
+	markOverdue(task)
+
+	log.Info("Task unassigned successfully", "id", id)
+	return task, nil
+}
+
+func (s *TaskServiceImpl) AddTaskDependency(ctx context.Context, id uint, blockerID uint) (*domain.Task, error) {
+	log := logger.GetLogger()
+	log.Info("Adding task dependency", "id", id, "blocker_id", blockerID)
+
+	if id == blockerID {
+		log.Error("Task cannot depend on itself", "id", id)
+		return nil, domain.NewValidationError("task cannot depend on itself")
+	}
+
+	err := s.taskRepo.AddDependency(ctx, id, blockerID)
+	if err != nil {
+		log.Error("Failed to add task dependency", "error", err, "id", id, "blocker_id", blockerID)
+		return nil, err
+	}
+
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		log.Error("Failed to reload task after adding dependency", "error", err, "id", id)
+		return nil, err
+	}
+
+	markOverdue(task)
+
+	log.Info("Task dependency added successfully", "id", id, "blocker_id", blockerID)
+	return task, nil
+}
+
+func (s *TaskServiceImpl) RemoveTaskDependency(ctx context.Context, id uint, blockerID uint) (*domain.Task, error) {
+	log := logger.GetLogger()
+	log.Info("Removing task dependency", "id", id, "blocker_id", blockerID)
+
+	err := s.taskRepo.RemoveDependency(ctx, id, blockerID)
+	if err != nil {
+		log.Error("Failed to remove task dependency", "error", err, "id", id, "blocker_id", blockerID)
+		return nil, err
+	}
+
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		log.Error("Failed to reload task after removing dependency", "error", err, "id", id)
+		return nil, err
+	}
+
+	markOverdue(task)
+
+	log.Info("Task dependency removed successfully", "id", id, "blocker_id", blockerID)
+	return task, nil
+}
+
+func (s *TaskServiceImpl) GetTaskDependencies(ctx context.Context, id uint) (*domain.TaskDependencies, error) {
+	log := logger.GetLogger()
+	log.Info("Getting task dependencies", "id", id)
+
+	if _, err := s.taskRepo.FindByID(ctx, id); err != nil {
+		log.Error("Failed to find task for dependencies", "error", err, "id", id)
+		return nil, err
+	}
+
+	dependencies, err := s.taskRepo.FindDependencies(ctx, id)
+	if err != nil {
+		log.Error("Failed to get task dependencies", "error", err, "id", id)
+		return nil, err
+	}
+
+	for i := range dependencies.BlockedBy {
+		markOverdue(&dependencies.BlockedBy[i])
+	}
+	for i := range dependencies.Blocks {
+		markOverdue(&dependencies.Blocks[i])
+	}
+
+	log.Info("Task dependencies retrieved successfully", "id", id,
+		"blocked_by", len(dependencies.BlockedBy), "blocks", len(dependencies.Blocks))
+	return dependencies, nil
+}
+
+func (s *TaskServiceImpl) AddTaskLabel(ctx context.Context, id uint, labelID uint) (*domain.Task, error) {
+	log := logger.GetLogger()
+	log.Info("Adding label to task", "id", id, "label_id", labelID)
+
+	task, err := s.taskRepo.AddLabel(ctx, id, labelID)
+	if err != nil {
+		log.Error("Failed to add label to task", "error", err, "id", id, "label_id", labelID)
 		return nil, err
 	}
 
-	log.Info("Task updated successfully", "id", id, "title", task.Title, "status", task.Status)
+	markOverdue(task)
+
+	log.Info("Label added to task successfully", "id", id, "label_id", labelID)
 	return task, nil
 }
 
-func (s *TaskServiceImpl) DeleteTask(ctx context.Context, id uint) error {
+func (s *TaskServiceImpl) RemoveTaskLabel(ctx context.Context, id uint, labelID uint) (*domain.Task, error) {
 	log := logger.GetLogger()
-	log.Info("Deleting task", "id", id)
+	log.Info("Removing label from task", "id", id, "label_id", labelID)
 
-	err := s.taskRepo.Delete(ctx, id)
+	task, err := s.taskRepo.RemoveLabel(ctx, id, labelID)
+	if err != nil {
+		log.Error("Failed to remove label from task", "error", err, "id", id, "label_id", labelID)
+		return nil, err
+	}
+
+	markOverdue(task)
+
+	log.Info("Label removed from task successfully", "id", id, "label_id", labelID)
+	return task, nil
+}
+
+func (s *TaskServiceImpl) GetAllowedTransitions(ctx context.Context, id uint) (*domain.Task, []domain.TaskStatus, error) {
+	log := logger.GetLogger()
+	log.Info("Getting allowed transitions", "id", id)
+
+	task, err := s.taskRepo.FindByID(ctx, id)
+	if err != nil {
+		log.Error("Failed to find task for transitions", "error", err, "id", id)
+		return nil, nil, err
+	}
+
+	return task, s.workflow.AllowedTransitions(task.Status), nil
+}
+
+func (s *TaskServiceImpl) DeleteTask(ctx context.Context, id uint, version *uint) error {
+	log := logger.GetLogger()
+	log.Info("Deleting task", "id", id, "version", version)
+
+	err := s.taskRepo.Delete(ctx, id, version)
 	if err != nil {
 		log.Error("Failed to delete task", "error", err, "id", id)
 		return err
@@ -133,3 +538,217 @@ func (s *TaskServiceImpl) DeleteTask(ctx context.Context, id uint) error {
 	log.Info("Task deleted successfully", "id", id)
 	return nil
 }
+
+func markOverdue(task *domain.Task) {
+	task.IsOverdue = task.IsOverdueAt(time.Now())
+}
+
+func (s *TaskServiceImpl) RestoreTask(ctx context.Context, id uint) (*domain.Task, error) {
+	log := logger.GetLogger()
+	log.Info("Restoring task", "id", id)
+
+	task, err := s.taskRepo.Restore(ctx, id)
+	if err != nil {
+		log.Error("Failed to restore task", "error", err, "id", id)
+		return nil, err
+	}
+
+	markOverdue(task)
+
+	log.Info("Task restored successfully", "id", id)
+	return task, nil
+}
+
+func (s *TaskServiceImpl) PurgeTask(ctx context.Context, id uint, version *uint) error {
+	log := logger.GetLogger()
+	log.Info("Purging task", "id", id, "version", version)
+
+	err := s.taskRepo.Purge(ctx, id, version)
+	if err != nil {
+		log.Error("Failed to purge task", "error", err, "id", id)
+		return err
+	}
+
+	log.Info("Task purged successfully", "id", id)
+	return nil
+}
+
+func (s *TaskServiceImpl) PurgeExpiredTasks(ctx context.Context, retention time.Duration) (int64, error) {
+	log := logger.GetLogger()
+
+	cutoff := time.Now().Add(-retention)
+	purged, err := s.taskRepo.PurgeDeletedBefore(ctx, cutoff)
+	if err != nil {
+		log.Error("Failed to purge expired tasks", "error", err, "deleted_before", cutoff)
+		return 0, err
+	}
+
+	if purged > 0 {
+		log.Info("Expired tasks purged", "count", purged, "deleted_before", cutoff)
+	}
+	return purged, nil
+}
+
+func (s *TaskServiceImpl) GetTaskHistory(ctx context.Context, id uint, page, limit int) ([]domain.TaskEvent, int64, error) {
+	log := logger.GetLogger()
+	log.Info("Getting task history", "id", id, "page", page, "limit", limit)
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	events, total, err := s.eventRepo.FindByTaskID(ctx, id, page, limit)
+	if err != nil {
+		log.Error("Failed to get task history", "error", err, "id", id)
+		return nil, 0, err
+	}
+
+	// History outlives deleted tasks, so only an empty history can mean the
+	// task never existed.
+	if total == 0 {
+		if _, err := s.taskRepo.FindByID(ctx, id); err != nil {
+			log.Error("Failed to find task for history", "error", err, "id", id)
+			return nil, 0, err
+		}
+	}
+
+	log.Info("Task history retrieved successfully", "id", id, "count", len(events), "total", total)
+	return events, total, nil
+}
+
+func (s *TaskServiceImpl) BulkTasks(ctx context.Context, operations []domain.BulkTaskOperation, atomic bool) ([]domain.BulkTaskResult, error) {
+	log := logger.GetLogger()
+	log.Info("Running bulk task operations", "count", len(operations), "atomic", atomic)
+
+	if len(operations) == 0 {
+		log.Error("Bulk request without operations")
+		return nil, domain.NewValidationError("no operations")
+	}
+	if len(operations) > domain.MaxBulkOperations {
+		log.Error("Too many bulk operations", "count", len(operations))
+		return nil, domain.NewValidationError("too many operations")
+	}
+
+	// Operations are validated against the tasks as they are now; the
+	// version checks of the repository catch writes that happen in between.
+	results := make([]domain.BulkTaskResult, len(operations))
+	changes := make([]domain.TaskChange, 0, len(operations))
+	indexes := make([]int, 0, len(operations))
+	seen := make(map[uint]bool)
+	for i, operation := range operations {
+		change, err := s.prepareTaskChange(ctx, operation, seen)
+		if err != nil {
+			log.Error("Invalid bulk operation", "error", err, "index", i, "type", operation.Type, "id", operation.ID)
+			results[i].Err = err
+			if atomic {
+				return abortBatch(results), nil
+			}
+			continue
+		}
+		changes = append(changes, change)
+		indexes = append(indexes, i)
+	}
+
+	errs, err := s.taskRepo.ApplyBatch(ctx, changes, atomic)
+	if err != nil {
+		log.Error("Failed to apply bulk operations", "error", err)
+		return nil, err
+	}
+
+	failed := len(operations) - len(changes)
+	for j, i := range indexes {
+		if errs[j] != nil {
+			log.Error("Bulk operation failed", "error", errs[j], "index", i, "type", operations[i].Type, "id", operations[i].ID)
+			results[i].Err = errs[j]
+			if atomic {
+				return abortBatch(results), nil
+			}
+			failed++
+			continue
+		}
+		if task := changes[j].Task; task != nil {
+			markOverdue(task)
+			results[i].Task = task
+		}
+	}
+
+	log.Info("Bulk task operations completed", "count", len(operations), "failed", failed)
+	return results, nil
+}
+
+func (s *TaskServiceImpl) UpdateTasks(ctx context.Context, ids []uint, input domain.UpdateTaskInput, atomic bool) ([]domain.BulkTaskResult, error) {
+	operations := make([]domain.BulkTaskOperation, len(ids))
+	for i, id := range ids {
+		operations[i] = domain.BulkTaskOperation{Type: domain.BulkOperationUpdate, ID: id, Update: input}
+	}
+	return s.BulkTasks(ctx, operations, atomic)
+}
+
+// prepareTaskChange validates an operation and turns it into the change the
+// repository applies. A batch may touch each task only once.
+func (s *TaskServiceImpl) prepareTaskChange(ctx context.Context, operation domain.BulkTaskOperation, seen map[uint]bool) (domain.TaskChange, error) {
+	change := domain.TaskChange{Type: operation.Type}
+	if operation.Type != domain.BulkOperationCreate {
+		if seen[operation.ID] {
+			return change, domain.NewValidationError("task appears more than once in batch")
+		}
+		seen[operation.ID] = true
+	}
+
+	switch operation.Type {
+	case domain.BulkOperationCreate:
+		task, err := s.newTask(ctx, operation.Create)
+		if err != nil {
+			return change, err
+		}
+		change.Task = task
+	case domain.BulkOperationUpdate:
+		task, err := s.taskRepo.FindByID(ctx, operation.ID)
+		if err != nil {
+			return change, err
+		}
+		if err := s.applyTaskUpdate(ctx, task, operation.Update); err != nil {
+			return change, err
+		}
+		change.Task = task
+	case domain.BulkOperationDelete:
+		change.ID = operation.ID
+		change.Version = operation.Version
+	default:
+		return change, domain.NewValidationError("invalid operation")
+	}
+	return change, nil
+}
+
+// abortBatch fails the operations of an atomic batch that were rolled back
+// because another one failed.
+func abortBatch(results []domain.BulkTaskResult) []domain.BulkTaskResult {
+	for i := range results {
+		if results[i].Err == nil {
+			results[i] = domain.BulkTaskResult{Err: domain.ErrBatchAborted}
+		}
+	}
+	return results
+}
+
+// normalizeTaskFilter defaults the sort order and defaults and validates the
+// label part of a filter.
+func normalizeTaskFilter(filter domain.TaskFilter) (domain.TaskFilter, error) {
+	if len(filter.Sort) == 0 {
+		filter.Sort = domain.DefaultTaskSort()
+	}
+	if len(filter.Labels) == 0 {
+		return filter, nil
+	}
+	if filter.LabelMode == "" {
+		filter.LabelMode = domain.LabelMatchAny
+	}
+	if !domain.IsValidLabelMatchMode(filter.LabelMode) {
+		return filter, domain.NewValidationError("invalid label mode")
+	}
+	filter.Labels = normalizeLabelNames(filter.Labels)
+	return filter, nil
+}
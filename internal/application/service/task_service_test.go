@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"errors"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,67 +13,549 @@ import (
 )
 
 type MockTaskRepository struct {
-	tasks  map[uint]*domain.Task
-	nextID uint
+	tasks        map[uint]*domain.Task
+	projects     map[uint]*domain.Project
+	labels       map[uint]*domain.Label
+	dependencies []domain.TaskDependency
+	events       []domain.TaskEvent
+	nextID       uint
 }
 
 func NewMockTaskRepository() *MockTaskRepository {
 	return &MockTaskRepository{
-		tasks:  make(map[uint]*domain.Task),
-		nextID: 1,
+		tasks:    make(map[uint]*domain.Task),
+		projects: make(map[uint]*domain.Project),
+		labels:   make(map[uint]*domain.Label),
+		nextID:   1,
 	}
 }
 
 func (m *MockTaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	if task.ParentID != nil {
+		if err := m.checkParent(task.ID, *task.ParentID); err != nil {
+			return err
+		}
+	}
+	if task.ProjectID != nil {
+		project, exists := m.projects[*task.ProjectID]
+		if !exists {
+			return domain.NewUnprocessableError("project not found")
+		}
+		if project.Archived {
+			return domain.NewConflictError("project is archived")
+		}
+		project.TaskCounter++
+		key := project.TaskKey(project.TaskCounter)
+		task.Key = &key
+	}
 	task.ID = m.nextID
+	task.Version = 1
 	task.CreatedAt = time.Now()
 	task.UpdatedAt = time.Now()
 	m.tasks[task.ID] = task
 	m.nextID++
+	m.record(ctx, task.ID, domain.TaskEventCreated, domain.DiffTasks(nil, task))
 	return nil
 }
 
 func (m *MockTaskRepository) FindByID(ctx context.Context, id uint) (*domain.Task, error) {
 	task, exists := m.tasks[id]
-	if !exists {
-		return nil, errors.New("task not found")
+	if !exists || task.DeletedAt != nil {
+		return nil, domain.NewNotFoundError("task not found")
+	}
+	m.derive(task)
+	// Hand out a copy, as a database would, so that callers see their
+	// changes only once they are saved.
+	found := *task
+	return &found, nil
+}
+
+func (m *MockTaskRepository) FindByKey(ctx context.Context, key string) (*domain.Task, error) {
+	for _, task := range m.tasks {
+		if task.Key != nil && *task.Key == key && task.DeletedAt == nil {
+			m.derive(task)
+			return task, nil
+		}
+	}
+	return nil, domain.NewNotFoundError("task not found")
+}
+
+func (m *MockTaskRepository) derive(task *domain.Task) {
+	task.Progress = m.progress(task.ID)
+	task.IsBlocked = false
+	for _, dependency := range m.dependencies {
+		blocker, exists := m.tasks[dependency.BlockerID]
+		if dependency.BlockedID == task.ID && exists && blocker.DeletedAt == nil && blocker.Status != domain.StatusDone {
+			task.IsBlocked = true
+		}
 	}
-	return task, nil
 }
 
-func (m *MockTaskRepository) FindAll(ctx context.Context, page, limit int, status *domain.TaskStatus) ([]domain.Task, int64, error) {
+func (m *MockTaskRepository) progress(id uint) domain.TaskProgress {
+	var progress domain.TaskProgress
+	for _, child := range m.tasks {
+		if child.ParentID == nil || *child.ParentID != id || child.DeletedAt != nil {
+			continue
+		}
+		progress.Total++
+		if child.Status == domain.StatusDone {
+			progress.Done++
+		}
+	}
+	return progress
+}
+
+// checkParent mirrors the hierarchy check the repository runs when a task
+// is saved with a parent.
+func (m *MockTaskRepository) checkParent(id, parentID uint) error {
+	if parentID == id {
+		return domain.NewConflictError("task hierarchy cannot contain cycles")
+	}
+	parent, exists := m.tasks[parentID]
+	if !exists || parent.DeletedAt != nil {
+		return domain.NewUnprocessableError("parent task not found")
+	}
+	for steps := 0; parent.ParentID != nil && steps < len(m.tasks); steps++ {
+		if *parent.ParentID == id {
+			return domain.NewConflictError("task hierarchy cannot contain cycles")
+		}
+		if parent, exists = m.tasks[*parent.ParentID]; !exists {
+			break
+		}
+	}
+	return nil
+}
+
+func (m *MockTaskRepository) FindAll(ctx context.Context, page, limit int, filter domain.TaskFilter) ([]domain.Task, int64, error) {
 	var tasks []domain.Task
 	for _, task := range m.tasks {
-		if status == nil || task.Status == *status {
-			tasks = append(tasks, *task)
+		if (task.DeletedAt != nil) != filter.Deleted {
+			continue
+		}
+		if len(filter.Statuses) > 0 && !containsStatus(filter.Statuses, task.Status) {
+			continue
+		}
+		if filter.TitleContains != "" && !strings.Contains(strings.ToLower(task.Title), strings.ToLower(filter.TitleContains)) {
+			continue
+		}
+		if (filter.CreatedAfter != nil && !task.CreatedAt.After(*filter.CreatedAfter)) ||
+			(filter.CreatedBefore != nil && !task.CreatedAt.Before(*filter.CreatedBefore)) ||
+			(filter.UpdatedAfter != nil && !task.UpdatedAt.After(*filter.UpdatedAfter)) ||
+			(filter.UpdatedBefore != nil && !task.UpdatedAt.Before(*filter.UpdatedBefore)) {
+			continue
 		}
+		if filter.Priority != nil && task.Priority != *filter.Priority {
+			continue
+		}
+		if filter.AssigneeID != nil && (task.AssigneeID == nil || *task.AssigneeID != *filter.AssigneeID) {
+			continue
+		}
+		if filter.Unassigned && task.AssigneeID != nil {
+			continue
+		}
+		if filter.CreatedBy != nil && (task.CreatedBy == nil || *task.CreatedBy != *filter.CreatedBy) {
+			continue
+		}
+		if len(filter.Labels) > 0 && !matchesLabels(task, filter.Labels, filter.LabelMode) {
+			continue
+		}
+		if filter.ProjectID != nil && (task.ProjectID == nil || *task.ProjectID != *filter.ProjectID) {
+			continue
+		}
+		if filter.ParentID != nil && (task.ParentID == nil || *task.ParentID != *filter.ParentID) {
+			continue
+		}
+		if filter.TopLevel && task.ParentID != nil {
+			continue
+		}
+		found := *task
+		m.derive(&found)
+		tasks = append(tasks, found)
 	}
+	order := filter.Sort
+	if len(order) == 0 {
+		order = domain.DefaultTaskSort()
+	}
+	sort.Slice(tasks, func(i, j int) bool { return compareTasks(&tasks[i], &tasks[j], order) < 0 })
 	return tasks, int64(len(tasks)), nil
 }
 
+func (m *MockTaskRepository) FindPage(ctx context.Context, filter domain.TaskFilter, request domain.TaskPageRequest) (*domain.TaskPage, error) {
+	tasks, total, _ := m.FindAll(ctx, 1, len(m.tasks), filter)
+	order := filter.Sort
+	if len(order) == 0 {
+		order = domain.DefaultTaskSort()
+	}
+
+	cursor := request.Cursor
+	window := tasks
+	if cursor != nil {
+		if _, err := cursor.SortValues(order); err != nil {
+			return nil, err
+		}
+		// The mock positions the cursor at its task instead of decoding
+		// the sort key values.
+		position := m.tasks[cursor.ID]
+		window = nil
+		for _, task := range tasks {
+			cmp := compareTasks(&task, position, order)
+			if (!cursor.Backward && cmp > 0) || (cursor.Backward && cmp < 0) {
+				window = append(window, task)
+			}
+		}
+	}
+
+	page := &domain.TaskPage{Limit: request.Limit}
+	if request.IncludeTotal {
+		page.Total = &total
+	}
+	backward := cursor != nil && cursor.Backward
+	hasMore := len(window) > request.Limit
+	if hasMore && backward {
+		window = window[len(window)-request.Limit:]
+	} else if hasMore {
+		window = window[:request.Limit]
+	}
+	if len(window) > 0 {
+		if hasMore || backward {
+			page.Next = domain.CursorAfter(&window[len(window)-1], order)
+		}
+		if (hasMore && backward) || (cursor != nil && !backward) {
+			page.Prev = domain.CursorBefore(&window[0], order)
+		}
+	}
+	page.Tasks = window
+	return page, nil
+}
+
+// compareTasks reports whether a is listed before (-1) or after (1) b in
+// the given order, tie-broken by ID.
+func compareTasks(a, b *domain.Task, order []domain.TaskSort) int {
+	for _, key := range order {
+		cmp := compareSortValues(key.ValueOf(a), key.ValueOf(b))
+		if key.Descending {
+			cmp = -cmp
+		}
+		if cmp != 0 {
+			return cmp
+		}
+	}
+	cmp := 0
+	if a.ID < b.ID {
+		cmp = -1
+	} else if a.ID > b.ID {
+		cmp = 1
+	}
+	if len(order) > 0 && order[len(order)-1].Descending {
+		cmp = -cmp
+	}
+	return cmp
+}
+
+func compareSortValues(a, b any) int {
+	switch a := a.(type) {
+	case int:
+		return a - b.(int)
+	case string:
+		return strings.Compare(a, b.(string))
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	case *time.Time:
+		// A missing due date sorts after every date.
+		b := b.(*time.Time)
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return 1
+		case b == nil:
+			return -1
+		}
+		return a.Compare(*b)
+	}
+	return 0
+}
+
+func containsStatus(statuses []domain.TaskStatus, status domain.TaskStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Search matches the query as a case-insensitive substring, like the
+// repository's fallback for databases without full-text search.
+func (m *MockTaskRepository) Search(ctx context.Context, query string, page, limit int, filter domain.TaskFilter) ([]domain.TaskSearchResult, int64, error) {
+	tasks, _, _ := m.FindAll(ctx, 1, len(m.tasks), filter)
+	query = strings.ToLower(query)
+
+	var results []domain.TaskSearchResult
+	for _, task := range tasks {
+		inTitle := strings.Contains(strings.ToLower(task.Title), query)
+		if !inTitle && !strings.Contains(strings.ToLower(task.Description), query) {
+			continue
+		}
+		result := domain.TaskSearchResult{Task: task, Rank: 0.5}
+		if inTitle {
+			result.Rank = 1
+		}
+		results = append(results, result)
+	}
+	return results, int64(len(results)), nil
+}
+
 func (m *MockTaskRepository) Update(ctx context.Context, task *domain.Task) error {
-	if _, exists := m.tasks[task.ID]; !exists {
-		return errors.New("task not found")
+	stored, exists := m.tasks[task.ID]
+	if !exists {
+		return domain.NewNotFoundError("task not found")
 	}
+	if stored.Version != task.Version {
+		return domain.ErrVersionMismatch
+	}
+	parentChanged := task.ParentID != nil && (stored.ParentID == nil || *stored.ParentID != *task.ParentID)
+	if parentChanged {
+		if err := m.checkParent(task.ID, *task.ParentID); err != nil {
+			return err
+		}
+	}
+	task.Version++
 	task.UpdatedAt = time.Now()
 	m.tasks[task.ID] = task
 	return nil
 }
 
-func (m *MockTaskRepository) Delete(ctx context.Context, id uint) error {
-	if _, exists := m.tasks[id]; !exists {
-		return errors.New("task not found")
+func (m *MockTaskRepository) Delete(ctx context.Context, id uint, version *uint) error {
+	task, exists := m.tasks[id]
+	if !exists || task.DeletedAt != nil {
+		return domain.NewNotFoundError("task not found")
+	}
+	if version != nil && task.Version != *version {
+		return domain.ErrVersionMismatch
+	}
+	task.Version++
+	deletedAt := time.Now()
+	task.DeletedAt = &deletedAt
+	m.record(ctx, id, domain.TaskEventDeleted, domain.DiffTasks(task, nil))
+	return nil
+}
+
+func (m *MockTaskRepository) Restore(ctx context.Context, id uint) (*domain.Task, error) {
+	task, exists := m.tasks[id]
+	if !exists || task.DeletedAt == nil {
+		return nil, domain.NewNotFoundError("task not found")
+	}
+	task.DeletedAt = nil
+	task.Version++
+	m.record(ctx, id, domain.TaskEventRestored, domain.FieldChanges{})
+	return task, nil
+}
+
+func (m *MockTaskRepository) Purge(ctx context.Context, id uint, version *uint) error {
+	task, exists := m.tasks[id]
+	if !exists {
+		return domain.NewNotFoundError("task not found")
 	}
+	if version != nil && task.Version != *version {
+		return domain.ErrVersionMismatch
+	}
+	m.record(ctx, id, domain.TaskEventPurged, domain.DiffTasks(task, nil))
 	delete(m.tasks, id)
 	return nil
 }
 
+func (m *MockTaskRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	var purged int64
+	for id, task := range m.tasks {
+		if task.DeletedAt != nil && task.DeletedAt.Before(cutoff) {
+			m.record(ctx, id, domain.TaskEventPurged, domain.DiffTasks(task, nil))
+			delete(m.tasks, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func (m *MockTaskRepository) AddLabel(ctx context.Context, taskID, labelID uint) (*domain.Task, error) {
+	task, err := m.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	label, exists := m.labels[labelID]
+	if !exists {
+		return nil, domain.NewNotFoundError("label not found")
+	}
+	for _, existing := range task.Labels {
+		if existing.ID == labelID {
+			return task, nil
+		}
+	}
+	task.Labels = append(task.Labels, *label)
+	m.tasks[taskID] = task
+	return task, nil
+}
+
+func (m *MockTaskRepository) RemoveLabel(ctx context.Context, taskID, labelID uint) (*domain.Task, error) {
+	task, err := m.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := m.labels[labelID]; !exists {
+		return nil, domain.NewNotFoundError("label not found")
+	}
+	for i, existing := range task.Labels {
+		if existing.ID == labelID {
+			task.Labels = append(task.Labels[:i], task.Labels[i+1:]...)
+			break
+		}
+	}
+	m.tasks[taskID] = task
+	return task, nil
+}
+
+func (m *MockTaskRepository) AddDependency(ctx context.Context, blockedID, blockerID uint) error {
+	if _, err := m.FindByID(ctx, blockedID); err != nil {
+		return err
+	}
+	if _, err := m.FindByID(ctx, blockerID); err != nil {
+		return domain.NewUnprocessableError("blocking task not found")
+	}
+
+	reachable := map[uint]bool{}
+	queue := []uint{blockedID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dependency := range m.dependencies {
+			if dependency.BlockerID == current && !reachable[dependency.BlockedID] {
+				reachable[dependency.BlockedID] = true
+				queue = append(queue, dependency.BlockedID)
+			}
+		}
+	}
+	if reachable[blockerID] {
+		return domain.NewConflictError("dependency would create a cycle")
+	}
+
+	for _, dependency := range m.dependencies {
+		if dependency.BlockerID == blockerID && dependency.BlockedID == blockedID {
+			return nil
+		}
+	}
+	m.dependencies = append(m.dependencies, domain.TaskDependency{BlockerID: blockerID, BlockedID: blockedID})
+	return nil
+}
+
+func (m *MockTaskRepository) RemoveDependency(ctx context.Context, blockedID, blockerID uint) error {
+	for i, dependency := range m.dependencies {
+		if dependency.BlockerID == blockerID && dependency.BlockedID == blockedID {
+			m.dependencies = append(m.dependencies[:i], m.dependencies[i+1:]...)
+			return nil
+		}
+	}
+	return domain.NewNotFoundError("dependency not found")
+}
+
+func (m *MockTaskRepository) FindDependencies(ctx context.Context, id uint) (*domain.TaskDependencies, error) {
+	dependencies := &domain.TaskDependencies{}
+	for _, dependency := range m.dependencies {
+		if dependency.BlockedID == id {
+			blocker := *m.tasks[dependency.BlockerID]
+			m.derive(&blocker)
+			dependencies.BlockedBy = append(dependencies.BlockedBy, blocker)
+		}
+		if dependency.BlockerID == id {
+			blocked := *m.tasks[dependency.BlockedID]
+			m.derive(&blocked)
+			dependencies.Blocks = append(dependencies.Blocks, blocked)
+		}
+	}
+	return dependencies, nil
+}
+
+// ApplyBatch restores a snapshot of the tasks when an atomic batch fails.
+func (m *MockTaskRepository) ApplyBatch(ctx context.Context, changes []domain.TaskChange, atomic bool) ([]error, error) {
+	snapshot := make(map[uint]domain.Task, len(m.tasks))
+	for id, task := range m.tasks {
+		snapshot[id] = *task
+	}
+	nextID, events := m.nextID, len(m.events)
+
+	errs := make([]error, len(changes))
+	for i, change := range changes {
+		switch change.Type {
+		case domain.BulkOperationCreate:
+			errs[i] = m.Create(ctx, change.Task)
+		case domain.BulkOperationUpdate:
+			errs[i] = m.Update(ctx, change.Task)
+		case domain.BulkOperationDelete:
+			errs[i] = m.Delete(ctx, change.ID, change.Version)
+		}
+		if errs[i] != nil && atomic {
+			m.tasks = make(map[uint]*domain.Task, len(snapshot))
+			for id, task := range snapshot {
+				restored := task
+				m.tasks[id] = &restored
+			}
+			m.nextID, m.events = nextID, m.events[:events]
+			break
+		}
+	}
+	return errs, nil
+}
+
+func matchesLabels(task *domain.Task, names []string, mode domain.LabelMatchMode) bool {
+	matched := 0
+	for _, name := range names {
+		for _, label := range task.Labels {
+			if label.Name == name {
+				matched++
+				break
+			}
+		}
+	}
+	if mode == domain.LabelMatchAll {
+		return matched == len(names)
+	}
+	return matched > 0
+}
+
+func (m *MockTaskRepository) record(ctx context.Context, taskID uint, eventType domain.TaskEventType, changes domain.FieldChanges) {
+	m.events = append(m.events, domain.TaskEvent{
+		ID:        uint(len(m.events) + 1),
+		TaskID:    taskID,
+		Type:      eventType,
+		Actor:     domain.ActorFromContext(ctx),
+		Changes:   changes,
+		CreatedAt: time.Now(),
+	})
+}
+
+type MockTaskEventRepository struct {
+	taskRepo *MockTaskRepository
+}
+
+func NewMockTaskEventRepository(taskRepo *MockTaskRepository) *MockTaskEventRepository {
+	return &MockTaskEventRepository{taskRepo: taskRepo}
+}
+
+func (m *MockTaskEventRepository) FindByTaskID(ctx context.Context, taskID uint, page, limit int) ([]domain.TaskEvent, int64, error) {
+	var events []domain.TaskEvent
+	for _, event := range m.taskRepo.events {
+		if event.TaskID == taskID {
+			events = append(events, event)
+		}
+	}
+	return events, int64(len(events)), nil
+}
+
 func TestCreateTask(t *testing.T) {
 	repo := NewMockTaskRepository()
-	service := NewTaskService(repo)
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
 	ctx := context.Background()
 
-	task, err := service.CreateTask(ctx, "Test Task", "Test Description")
+	task, err := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Test Task", Description: "Test Description"})
 	if err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
@@ -82,25 +567,98 @@ func TestCreateTask(t *testing.T) {
 	if task.Status != domain.StatusToDo {
 		t.Errorf("Expected status 'TO_DO', got %s", task.Status)
 	}
+
+	if task.Priority != domain.PriorityMedium {
+		t.Errorf("Expected priority 'MEDIUM', got %s", task.Priority)
+	}
+}
+
+func TestCreateTaskWithInvalidPriority(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	_, err := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Test Task", Priority: "CRITICAL"})
+	if err == nil {
+		t.Error("Expected error for invalid priority")
+	}
+}
+
+func TestUpdateTaskPriority(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	createdTask, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Test Task", Priority: domain.PriorityLow})
+
+	urgent := domain.PriorityUrgent
+	task, err := service.UpdateTask(ctx, createdTask.ID, domain.UpdateTaskInput{Priority: &urgent})
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if task.Priority != domain.PriorityUrgent {
+		t.Errorf("Expected priority 'URGENT', got %s", task.Priority)
+	}
+
+	invalid := domain.TaskPriority("SOMEDAY")
+	if _, err := service.UpdateTask(ctx, createdTask.ID, domain.UpdateTaskInput{Priority: &invalid}); err == nil {
+		t.Error("Expected error for invalid priority")
+	}
 }
 
 func TestCreateTaskWithEmptyTitle(t *testing.T) {
 	repo := NewMockTaskRepository()
-	service := NewTaskService(repo)
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
 	ctx := context.Background()
 
-	_, err := service.CreateTask(ctx, "", "Test Description")
+	_, err := service.CreateTask(ctx, domain.CreateTaskInput{Title: "", Description: "Test Description"})
 	if err == nil {
 		t.Error("Expected error for empty title")
 	}
 }
 
+func TestTaskTitleIsNormalized(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	task, err := service.CreateTask(ctx, domain.CreateTaskInput{Title: "  Write \n release   notes "})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if task.Title != "Write release notes" {
+		t.Errorf("Expected title 'Write release notes', got %q", task.Title)
+	}
+
+	blank := " \t "
+	_, err = service.UpdateTask(ctx, task.ID, domain.UpdateTaskInput{Title: &blank})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Errorf("Expected validation error for a blank title, got %v", err)
+	}
+}
+
+func TestUpdateTaskReportsInvalidField(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	task, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Test Task"})
+	status := domain.TaskStatus("BLOCKED")
+	_, err := service.UpdateTask(ctx, task.ID, domain.UpdateTaskInput{Status: &status})
+
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) || len(domainErr.Fields) != 1 || domainErr.Fields[0].Code != "invalid_status" {
+		t.Errorf("Expected invalid_status field error, got %v", err)
+	}
+}
+
 func TestGetTaskByID(t *testing.T) {
 	repo := NewMockTaskRepository()
-	service := NewTaskService(repo)
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
 	ctx := context.Background()
 
-	createdTask, _ := service.CreateTask(ctx, "Test Task", "Test Description")
+	createdTask, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Test Task", Description: "Test Description"})
 	retrievedTask, err := service.GetTaskByID(ctx, createdTask.ID)
 
 	if err != nil {
@@ -114,7 +672,7 @@ func TestGetTaskByID(t *testing.T) {
 
 func TestGetTaskByIDNotFound(t *testing.T) {
 	repo := NewMockTaskRepository()
-	service := NewTaskService(repo)
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
 	ctx := context.Background()
 
 	_, err := service.GetTaskByID(ctx, 999)
@@ -122,3 +680,915 @@ func TestGetTaskByIDNotFound(t *testing.T) {
 		t.Error("Expected error for non-existent task")
 	}
 }
+
+func TestCreateTaskWithStartAfterDue(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	due := time.Now().Add(time.Hour)
+	start := due.Add(time.Hour)
+	_, err := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Test Task", StartAt: &start, DueAt: &due})
+	if err == nil {
+		t.Error("Expected error when start date is after due date")
+	}
+}
+
+func TestTaskOverdueFlag(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	due := time.Now().Add(-time.Hour)
+	createdTask, err := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Test Task", DueAt: &due})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !createdTask.IsOverdue {
+		t.Error("Expected task past its due date to be overdue")
+	}
+
+	inProgress := domain.StatusInProgress
+	if _, err := service.UpdateTask(ctx, createdTask.ID, domain.UpdateTaskInput{Status: &inProgress}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	done := domain.StatusDone
+	task, err := service.UpdateTask(ctx, createdTask.ID, domain.UpdateTaskInput{Status: &done})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if task.IsOverdue {
+		t.Error("Expected DONE task not to be overdue")
+	}
+}
+
+func TestUpdateTaskRejectsInvalidTransition(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	createdTask, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Test Task"})
+
+	done := domain.StatusDone
+	_, err := service.UpdateTask(ctx, createdTask.ID, domain.UpdateTaskInput{Status: &done})
+
+	var transitionErr *domain.TransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("Expected transition error, got %v", err)
+	}
+
+	if !reflect.DeepEqual(transitionErr.Allowed, []domain.TaskStatus{domain.StatusInProgress}) {
+		t.Errorf("Expected allowed transitions [IN_PROGRESS], got %v", transitionErr.Allowed)
+	}
+}
+
+func TestGetAllowedTransitions(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	createdTask, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Test Task"})
+
+	inProgress := domain.StatusInProgress
+	service.UpdateTask(ctx, createdTask.ID, domain.UpdateTaskInput{Status: &inProgress})
+
+	_, allowed, err := service.GetAllowedTransitions(ctx, createdTask.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []domain.TaskStatus{domain.StatusToDo, domain.StatusDone}
+	if !reflect.DeepEqual(allowed, expected) {
+		t.Errorf("Expected allowed transitions %v, got %v", expected, allowed)
+	}
+}
+
+func TestCustomWorkflowAllowsConfiguredTransitions(t *testing.T) {
+	workflow, err := domain.ParseWorkflow(map[string]string{
+		"TO_DO":       "IN_PROGRESS|DONE",
+		"IN_PROGRESS": "DONE",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), workflow)
+	ctx := context.Background()
+
+	createdTask, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Test Task"})
+
+	done := domain.StatusDone
+	if _, err := service.UpdateTask(ctx, createdTask.ID, domain.UpdateTaskInput{Status: &done}); err != nil {
+		t.Errorf("Expected TO_DO to DONE to be allowed, got %v", err)
+	}
+
+	todo := domain.StatusToDo
+	if _, err := service.UpdateTask(ctx, createdTask.ID, domain.UpdateTaskInput{Status: &todo}); err == nil {
+		t.Error("Expected DONE to TO_DO to be rejected")
+	}
+
+	if _, err := domain.ParseWorkflow(map[string]string{"TO_DO": "ARCHIVED"}); err == nil {
+		t.Error("Expected error for unknown status in workflow")
+	}
+}
+
+func TestGetTaskHistory(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := domain.ContextWithUser(context.Background(), &domain.User{ID: 1, Username: "alice"})
+
+	createdTask, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Test Task"})
+	if err := service.DeleteTask(ctx, createdTask.ID, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	events, total, err := service.GetTaskHistory(ctx, createdTask.ID, 1, 10)
+	if err != nil {
+		t.Fatalf("Expected history of deleted task, got %v", err)
+	}
+
+	if total != 2 {
+		t.Fatalf("Expected 2 events, got %d", total)
+	}
+
+	if events[0].Type != domain.TaskEventCreated || events[0].Actor != "alice" {
+		t.Errorf("Expected CREATED event by alice, got %s by %s", events[0].Type, events[0].Actor)
+	}
+}
+
+func TestGetTaskHistoryNotFound(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	_, _, err := service.GetTaskHistory(ctx, 999, 1, 10)
+	if err == nil {
+		t.Error("Expected error for non-existent task")
+	}
+}
+
+func TestDeleteTaskMovesToTrash(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	createdTask, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Test Task"})
+	if err := service.DeleteTask(ctx, createdTask.ID, nil); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := service.GetTaskByID(ctx, createdTask.ID); err == nil {
+		t.Error("Expected trashed task to be hidden")
+	}
+
+	trashed, total, _ := service.GetTasks(ctx, 1, 10, domain.TaskFilter{Deleted: true})
+	if total != 1 || trashed[0].ID != createdTask.ID {
+		t.Errorf("Expected trashed task in trash listing, got %v", trashed)
+	}
+
+	restored, err := service.RestoreTask(ctx, createdTask.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if restored.DeletedAt != nil {
+		t.Error("Expected restored task to leave the trash")
+	}
+
+	if _, err := service.RestoreTask(ctx, createdTask.ID); err == nil {
+		t.Error("Expected error restoring a task that is not in the trash")
+	}
+}
+
+func TestPurgeExpiredTasks(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	expired, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Expired Task"})
+	recent, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Recent Task"})
+	service.DeleteTask(ctx, expired.ID, nil)
+	service.DeleteTask(ctx, recent.ID, nil)
+	deletedAt := time.Now().Add(-48 * time.Hour)
+	repo.tasks[expired.ID].DeletedAt = &deletedAt
+
+	purged, err := service.PurgeExpiredTasks(ctx, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if purged != 1 {
+		t.Errorf("Expected 1 purged task, got %d", purged)
+	}
+
+	if _, err := service.RestoreTask(ctx, expired.ID); err == nil {
+		t.Error("Expected purged task to be gone")
+	}
+
+	if _, err := service.RestoreTask(ctx, recent.ID); err != nil {
+		t.Errorf("Expected recently trashed task to be restorable, got %v", err)
+	}
+}
+
+func TestAssignTask(t *testing.T) {
+	repo := NewMockTaskRepository()
+	userRepo := NewMockUserRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), userRepo, domain.DefaultWorkflow())
+
+	alice := &domain.User{Username: "alice", Role: domain.RoleEditor}
+	bob := &domain.User{Username: "bob", Role: domain.RoleEditor}
+	userRepo.Create(context.Background(), alice)
+	userRepo.Create(context.Background(), bob)
+	ctx := domain.ContextWithUser(context.Background(), alice)
+
+	task, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Test Task"})
+	if task.CreatedBy == nil || *task.CreatedBy != alice.ID {
+		t.Errorf("Expected task to be created by %d, got %v", alice.ID, task.CreatedBy)
+	}
+
+	assigned, err := service.AssignTask(ctx, task.ID, bob.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if assigned.AssigneeID == nil || *assigned.AssigneeID != bob.ID {
+		t.Errorf("Expected assignee %d, got %v", bob.ID, assigned.AssigneeID)
+	}
+
+	tasks, _, _ := service.GetTasks(ctx, 1, 10, domain.TaskFilter{AssigneeID: &bob.ID})
+	if len(tasks) != 1 {
+		t.Errorf("Expected 1 task assigned to bob, got %d", len(tasks))
+	}
+	tasks, _, _ = service.GetTasks(ctx, 1, 10, domain.TaskFilter{AssigneeID: &alice.ID})
+	if len(tasks) != 0 {
+		t.Errorf("Expected 0 tasks assigned to alice, got %d", len(tasks))
+	}
+
+	unassigned, err := service.UnassignTask(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if unassigned.AssigneeID != nil {
+		t.Errorf("Expected no assignee, got %v", *unassigned.AssigneeID)
+	}
+}
+
+func TestAssignTaskValidatesAssignee(t *testing.T) {
+	repo := NewMockTaskRepository()
+	userRepo := NewMockUserRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), userRepo, domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	task, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Test Task"})
+
+	_, err := service.AssignTask(ctx, task.ID, 42)
+	if err == nil || err.Error() != "assignee not found" {
+		t.Errorf("Expected assignee not found error, got %v", err)
+	}
+
+	carol := &domain.User{Username: "carol", Role: domain.RoleEditor, Disabled: true}
+	userRepo.Create(ctx, carol)
+
+	_, err = service.AssignTask(ctx, task.ID, carol.ID)
+	if err == nil || err.Error() != "assignee is disabled" {
+		t.Errorf("Expected assignee is disabled error, got %v", err)
+	}
+}
+
+func TestTaskLabels(t *testing.T) {
+	repo := NewMockTaskRepository()
+	repo.labels[1] = &domain.Label{ID: 1, Name: "bug", Color: "#ff0000"}
+	repo.labels[2] = &domain.Label{ID: 2, Name: "infra", Color: "#00ff00"}
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	both, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Both"})
+	bugOnly, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Bug only"})
+	service.CreateTask(ctx, domain.CreateTaskInput{Title: "None"})
+
+	service.AddTaskLabel(ctx, both.ID, 1)
+	service.AddTaskLabel(ctx, both.ID, 2)
+	task, err := service.AddTaskLabel(ctx, bugOnly.ID, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(task.Labels) != 1 || task.Labels[0].Name != "bug" {
+		t.Errorf("Expected task to carry the bug label, got %v", task.Labels)
+	}
+
+	tasks, _, err := service.GetTasks(ctx, 1, 10, domain.TaskFilter{Labels: []string{"Bug", "infra"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Errorf("Expected 2 tasks matching any label, got %d", len(tasks))
+	}
+
+	tasks, _, _ = service.GetTasks(ctx, 1, 10, domain.TaskFilter{Labels: []string{"bug", "infra", "bug"}, LabelMode: domain.LabelMatchAll})
+	if len(tasks) != 1 || tasks[0].ID != both.ID {
+		t.Errorf("Expected only task %d to match all labels, got %d tasks", both.ID, len(tasks))
+	}
+
+	if _, _, err := service.GetTasks(ctx, 1, 10, domain.TaskFilter{Labels: []string{"bug"}, LabelMode: "some"}); err == nil {
+		t.Error("Expected error for invalid label mode")
+	}
+
+	task, _ = service.RemoveTaskLabel(ctx, both.ID, 2)
+	if len(task.Labels) != 1 {
+		t.Errorf("Expected 1 label after removal, got %d", len(task.Labels))
+	}
+
+	if _, err := service.AddTaskLabel(ctx, both.ID, 99); err == nil || err.Error() != "label not found" {
+		t.Errorf("Expected label not found error, got %v", err)
+	}
+}
+
+func TestSubtaskProgressAndDoneRule(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	parent, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Parent"})
+	first, err := service.CreateTask(ctx, domain.CreateTaskInput{Title: "First", ParentID: &parent.ID})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	service.CreateTask(ctx, domain.CreateTaskInput{Title: "Second", ParentID: &parent.ID})
+
+	inProgress := domain.StatusInProgress
+	done := domain.StatusDone
+	service.UpdateTask(ctx, first.ID, domain.UpdateTaskInput{Status: &inProgress})
+	service.UpdateTask(ctx, first.ID, domain.UpdateTaskInput{Status: &done})
+
+	got, _ := service.GetTaskByID(ctx, parent.ID)
+	if got.Progress.Done != 1 || got.Progress.Total != 2 {
+		t.Errorf("Expected progress 1/2, got %d/%d", got.Progress.Done, got.Progress.Total)
+	}
+
+	children, total, _ := service.GetTasks(ctx, 1, 10, domain.TaskFilter{ParentID: &parent.ID})
+	if total != 2 || len(children) != 2 {
+		t.Errorf("Expected 2 children, got %d", total)
+	}
+
+	service.UpdateTask(ctx, parent.ID, domain.UpdateTaskInput{Status: &inProgress})
+	_, err = service.UpdateTask(ctx, parent.ID, domain.UpdateTaskInput{Status: &done})
+	if err == nil || err.Error() != "task has open subtasks" {
+		t.Errorf("Expected open subtasks error, got %v", err)
+	}
+
+	updated, err := service.UpdateTask(ctx, parent.ID, domain.UpdateTaskInput{Status: &done, Force: true})
+	if err != nil {
+		t.Fatalf("Expected forced update to succeed, got %v", err)
+	}
+	if updated.Status != domain.StatusDone {
+		t.Errorf("Expected status DONE, got %s", updated.Status)
+	}
+}
+
+func TestUpdateTaskParentPreventsCycles(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	root, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Root"})
+	child, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Child", ParentID: &root.ID})
+	grandchild, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Grandchild", ParentID: &child.ID})
+
+	_, err := service.UpdateTask(ctx, root.ID, domain.UpdateTaskInput{ParentID: &grandchild.ID})
+	if err == nil || err.Error() != "task hierarchy cannot contain cycles" {
+		t.Errorf("Expected cycle error, got %v", err)
+	}
+
+	_, err = service.UpdateTask(ctx, root.ID, domain.UpdateTaskInput{ParentID: &root.ID})
+	if err == nil || err.Error() != "task hierarchy cannot contain cycles" {
+		t.Errorf("Expected cycle error for self-parent, got %v", err)
+	}
+
+	missing := uint(99)
+	_, err = service.UpdateTask(ctx, child.ID, domain.UpdateTaskInput{ParentID: &missing})
+	if err == nil || err.Error() != "parent task not found" {
+		t.Errorf("Expected parent task not found error, got %v", err)
+	}
+
+	updated, err := service.UpdateTask(ctx, grandchild.ID, domain.UpdateTaskInput{ClearParent: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.ParentID != nil {
+		t.Errorf("Expected parent to be cleared, got %v", *updated.ParentID)
+	}
+}
+
+func TestTaskDependencies(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	a, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "A"})
+	b, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "B"})
+	c, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "C"})
+
+	// A blocks B, B blocks C
+	blocked, err := service.AddTaskDependency(ctx, b.ID, a.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !blocked.IsBlocked {
+		t.Error("Expected B to be blocked by A")
+	}
+	service.AddTaskDependency(ctx, c.ID, b.ID)
+
+	if _, err := service.AddTaskDependency(ctx, a.ID, c.ID); err == nil || err.Error() != "dependency would create a cycle" {
+		t.Errorf("Expected cycle error, got %v", err)
+	}
+	if _, err := service.AddTaskDependency(ctx, a.ID, a.ID); err == nil || err.Error() != "task cannot depend on itself" {
+		t.Errorf("Expected self-dependency error, got %v", err)
+	}
+
+	dependencies, err := service.GetTaskDependencies(ctx, b.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(dependencies.BlockedBy) != 1 || dependencies.BlockedBy[0].ID != a.ID {
+		t.Errorf("Expected B to be blocked by A, got %v", dependencies.BlockedBy)
+	}
+	if len(dependencies.Blocks) != 1 || dependencies.Blocks[0].ID != c.ID {
+		t.Errorf("Expected B to block C, got %v", dependencies.Blocks)
+	}
+
+	removed, err := service.RemoveTaskDependency(ctx, b.ID, a.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if removed.IsBlocked {
+		t.Error("Expected B to be unblocked after removing the dependency")
+	}
+}
+
+func TestBlockedTaskCannotStartWithoutForce(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	blocker, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Blocker"})
+	task, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Blocked"})
+	service.AddTaskDependency(ctx, task.ID, blocker.ID)
+
+	inProgress := domain.StatusInProgress
+	_, err := service.UpdateTask(ctx, task.ID, domain.UpdateTaskInput{Status: &inProgress})
+	if err == nil || err.Error() != "task is blocked by open dependencies" {
+		t.Errorf("Expected blocked error, got %v", err)
+	}
+
+	done := domain.StatusDone
+	service.UpdateTask(ctx, blocker.ID, domain.UpdateTaskInput{Status: &inProgress})
+	service.UpdateTask(ctx, blocker.ID, domain.UpdateTaskInput{Status: &done})
+
+	updated, err := service.UpdateTask(ctx, task.ID, domain.UpdateTaskInput{Status: &inProgress})
+	if err != nil {
+		t.Fatalf("Expected task to start once its blocker is done, got %v", err)
+	}
+	if updated.Status != domain.StatusInProgress {
+		t.Errorf("Expected status IN_PROGRESS, got %s", updated.Status)
+	}
+}
+
+func TestBlockedTaskStartsWithForce(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	blocker, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Blocker"})
+	task, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Blocked"})
+	service.AddTaskDependency(ctx, task.ID, blocker.ID)
+
+	inProgress := domain.StatusInProgress
+	if _, err := service.UpdateTask(ctx, task.ID, domain.UpdateTaskInput{Status: &inProgress, Force: true}); err != nil {
+		t.Errorf("Expected forced start to succeed, got %v", err)
+	}
+}
+
+func TestCreateTaskInProjectAssignsSequentialKeys(t *testing.T) {
+	repo := NewMockTaskRepository()
+	repo.projects[1] = &domain.Project{ID: 1, Key: "OPS", Name: "Operations"}
+	repo.projects[2] = &domain.Project{ID: 2, Key: "WEB", Name: "Website", Archived: true}
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	projectID := uint(1)
+	first, err := service.CreateTask(ctx, domain.CreateTaskInput{Title: "First", ProjectID: &projectID})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	second, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Second", ProjectID: &projectID})
+	service.CreateTask(ctx, domain.CreateTaskInput{Title: "Unscoped"})
+
+	if first.Key == nil || *first.Key != "OPS-1" {
+		t.Errorf("Expected key OPS-1, got %v", first.Key)
+	}
+	if second.Key == nil || *second.Key != "OPS-2" {
+		t.Errorf("Expected key OPS-2, got %v", second.Key)
+	}
+
+	found, err := service.GetTaskByKey(ctx, "OPS-2")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if found.ID != second.ID {
+		t.Errorf("Expected task %d, got %d", second.ID, found.ID)
+	}
+
+	tasks, total, _ := service.GetTasks(ctx, 1, 10, domain.TaskFilter{ProjectID: &projectID})
+	if total != 2 || len(tasks) != 2 {
+		t.Errorf("Expected 2 tasks in project, got %d", total)
+	}
+
+	archivedID := uint(2)
+	if _, err := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Late", ProjectID: &archivedID}); err == nil || err.Error() != "project is archived" {
+		t.Errorf("Expected project is archived error, got %v", err)
+	}
+}
+
+func TestSearchTasks(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	service.CreateTask(ctx, domain.CreateTaskInput{Title: "Fix login redirect", Description: "Users land on a blank page"})
+	service.CreateTask(ctx, domain.CreateTaskInput{Title: "Update docs", Description: "Describe the login flow"})
+	started, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Login audit"})
+	inProgress := domain.StatusInProgress
+	service.UpdateTask(ctx, started.ID, domain.UpdateTaskInput{Status: &inProgress})
+
+	results, total, err := service.SearchTasks(ctx, "  LOGIN ", 1, 10, domain.TaskFilter{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if total != 3 || len(results) != 3 {
+		t.Errorf("Expected 3 results, got %d", total)
+	}
+
+	toDo := domain.StatusToDo
+	_, total, _ = service.SearchTasks(ctx, "login", 1, 10, domain.TaskFilter{Statuses: []domain.TaskStatus{toDo}})
+	if total != 2 {
+		t.Errorf("Expected 2 results with status filter, got %d", total)
+	}
+
+	if _, _, err := service.SearchTasks(ctx, "   ", 1, 10, domain.TaskFilter{}); err == nil || err.Error() != "search query is required" {
+		t.Errorf("Expected search query is required error, got %v", err)
+	}
+	if _, _, err := service.SearchTasks(ctx, strings.Repeat("a", domain.MaxSearchQueryLength+1), 1, 10, domain.TaskFilter{}); err == nil || err.Error() != "search query is too long" {
+		t.Errorf("Expected search query is too long error, got %v", err)
+	}
+}
+
+func TestGetTaskPageWalksBothDirections(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		service.CreateTask(ctx, domain.CreateTaskInput{Title: "Task"})
+	}
+	urgent, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Urgent", Priority: domain.PriorityUrgent})
+
+	first, err := service.GetTaskPage(ctx, domain.TaskFilter{}, domain.TaskPageRequest{Limit: 4, IncludeTotal: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(first.Tasks) != 4 || first.Tasks[0].ID != urgent.ID {
+		t.Fatalf("Expected 4 tasks starting with the urgent one, got %+v", first.Tasks)
+	}
+	if first.Total == nil || *first.Total != 6 {
+		t.Errorf("Expected total 6, got %v", first.Total)
+	}
+	if first.Next == nil || first.Prev != nil {
+		t.Fatalf("Expected only a next cursor on the first page, got next=%v prev=%v", first.Next, first.Prev)
+	}
+
+	decoded, err := domain.DecodeTaskCursor(first.Next.Encode())
+	if err != nil {
+		t.Fatalf("Expected cursor to round-trip, got %v", err)
+	}
+	second, _ := service.GetTaskPage(ctx, domain.TaskFilter{}, domain.TaskPageRequest{Limit: 4, Cursor: decoded})
+	if len(second.Tasks) != 2 || second.Next != nil || second.Prev == nil {
+		t.Fatalf("Expected last page of 2 tasks with a prev cursor, got %d tasks next=%v", len(second.Tasks), second.Next)
+	}
+	if second.Total != nil {
+		t.Errorf("Expected no total unless requested, got %d", *second.Total)
+	}
+
+	// A task created meanwhile past the cursor does not shift the pages already seen.
+	service.CreateTask(ctx, domain.CreateTaskInput{Title: "Newest", Priority: domain.PriorityLow})
+
+	back, _ := service.GetTaskPage(ctx, domain.TaskFilter{}, domain.TaskPageRequest{Limit: 4, Cursor: second.Prev})
+	if len(back.Tasks) != 4 {
+		t.Fatalf("Expected 4 tasks going back, got %d", len(back.Tasks))
+	}
+	for i := range back.Tasks {
+		if back.Tasks[i].ID != first.Tasks[i].ID {
+			t.Errorf("Expected task %d at position %d, got %d", first.Tasks[i].ID, i, back.Tasks[i].ID)
+		}
+	}
+	if back.Prev != nil {
+		t.Error("Expected no prev cursor when back on the first page")
+	}
+}
+
+func TestDecodeTaskCursorRejectsGarbage(t *testing.T) {
+	for _, cursor := range []string{"not-base64!", "e30", "bnVsbA"} {
+		if _, err := domain.DecodeTaskCursor(cursor); err == nil {
+			t.Errorf("Expected error for cursor %q", cursor)
+		}
+	}
+}
+
+func TestGetTaskPageWithCustomSort(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	soon := time.Now().Add(24 * time.Hour)
+	later := soon.Add(24 * time.Hour)
+	service.CreateTask(ctx, domain.CreateTaskInput{Title: "No date"})
+	service.CreateTask(ctx, domain.CreateTaskInput{Title: "Later", DueAt: &later})
+	service.CreateTask(ctx, domain.CreateTaskInput{Title: "Also no date"})
+	service.CreateTask(ctx, domain.CreateTaskInput{Title: "Soon", DueAt: &soon})
+
+	sort, _ := domain.ParseTaskSort("due_at,title")
+	filter := domain.TaskFilter{Sort: sort}
+
+	var titles []string
+	request := domain.TaskPageRequest{Limit: 3}
+	for {
+		page, err := service.GetTaskPage(ctx, filter, request)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		for _, task := range page.Tasks {
+			titles = append(titles, task.Title)
+		}
+		if page.Next == nil {
+			break
+		}
+		request.Cursor = page.Next
+	}
+
+	expected := []string{"Soon", "Later", "Also no date", "No date"}
+	if !reflect.DeepEqual(titles, expected) {
+		t.Errorf("Expected %v, got %v", expected, titles)
+	}
+
+	_, err := service.GetTaskPage(ctx, domain.TaskFilter{}, request)
+	if err == nil || err.Error() != "invalid cursor" {
+		t.Errorf("Expected invalid cursor error for a cursor of another order, got %v", err)
+	}
+}
+
+func TestGetTasksFiltersByStatusesAndTitle(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	inProgress := domain.StatusInProgress
+	done := domain.StatusDone
+	started, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Fix login page"})
+	service.UpdateTask(ctx, started.ID, domain.UpdateTaskInput{Status: &inProgress})
+	finished, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Fix logout"})
+	service.UpdateTask(ctx, finished.ID, domain.UpdateTaskInput{Status: &inProgress})
+	service.UpdateTask(ctx, finished.ID, domain.UpdateTaskInput{Status: &done})
+	service.CreateTask(ctx, domain.CreateTaskInput{Title: "Write LOGIN docs"})
+
+	_, total, _ := service.GetTasks(ctx, 1, 10, domain.TaskFilter{Statuses: []domain.TaskStatus{domain.StatusToDo, domain.StatusInProgress}})
+	if total != 2 {
+		t.Errorf("Expected 2 open tasks, got %d", total)
+	}
+
+	tasks, total, _ := service.GetTasks(ctx, 1, 10, domain.TaskFilter{TitleContains: "login"})
+	if total != 2 {
+		t.Errorf("Expected 2 tasks mentioning login, got %d", total)
+	}
+	for _, task := range tasks {
+		if task.ID == finished.ID {
+			t.Errorf("Expected task %d not to match", finished.ID)
+		}
+	}
+}
+
+func TestUpdateTaskChecksVersion(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	task, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Task"})
+	if task.Version != 1 {
+		t.Fatalf("Expected version 1, got %d", task.Version)
+	}
+
+	stale := task.Version
+	title := "Renamed"
+	updated, err := service.UpdateTask(ctx, task.ID, domain.UpdateTaskInput{Title: &title, Version: &stale})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.Version != 2 {
+		t.Errorf("Expected version 2, got %d", updated.Version)
+	}
+
+	other := "Overwritten"
+	if _, err := service.UpdateTask(ctx, task.ID, domain.UpdateTaskInput{Title: &other, Version: &stale}); err == nil || err.Error() != "task version mismatch" {
+		t.Errorf("Expected task version mismatch error, got %v", err)
+	}
+	if err := service.DeleteTask(ctx, task.ID, &stale); err == nil || err.Error() != "task version mismatch" {
+		t.Errorf("Expected task version mismatch error, got %v", err)
+	}
+
+	current, _ := service.GetTaskByID(ctx, task.ID)
+	if current.Title != "Renamed" {
+		t.Errorf("Expected title to stay Renamed, got %s", current.Title)
+	}
+	if err := service.DeleteTask(ctx, task.ID, &current.Version); err != nil {
+		t.Errorf("Expected delete at the current version to succeed, got %v", err)
+	}
+}
+
+func TestBulkTasksReportsEachOperation(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	first, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "First"})
+	second, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Second"})
+
+	inProgress := domain.StatusInProgress
+	done := domain.StatusDone
+	results, err := service.BulkTasks(ctx, []domain.BulkTaskOperation{
+		{Type: domain.BulkOperationCreate, Create: domain.CreateTaskInput{Title: "Third"}},
+		{Type: domain.BulkOperationUpdate, ID: first.ID, Update: domain.UpdateTaskInput{Status: &inProgress}},
+		{Type: domain.BulkOperationDelete, ID: 999},
+		{Type: domain.BulkOperationUpdate, ID: second.ID, Update: domain.UpdateTaskInput{Status: &done}},
+	}, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if results[0].Err != nil || results[0].Task == nil || results[0].Task.Title != "Third" {
+		t.Errorf("Expected task to be created, got %+v", results[0])
+	}
+	if results[1].Err != nil || results[1].Task.Status != domain.StatusInProgress {
+		t.Errorf("Expected task to be started, got %+v", results[1])
+	}
+	if results[2].Err == nil || results[2].Err.Error() != "task not found" {
+		t.Errorf("Expected task not found error, got %v", results[2].Err)
+	}
+	var transitionErr *domain.TransitionError
+	if !errors.As(results[3].Err, &transitionErr) {
+		t.Errorf("Expected transition error, got %v", results[3].Err)
+	}
+
+	_, total, _ := service.GetTasks(ctx, 1, 10, domain.TaskFilter{})
+	if total != 3 {
+		t.Errorf("Expected 3 tasks, got %d", total)
+	}
+}
+
+func TestAtomicBulkTasksRollsBackOnFailure(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	task, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Kept"})
+
+	results, err := service.BulkTasks(ctx, []domain.BulkTaskOperation{
+		{Type: domain.BulkOperationCreate, Create: domain.CreateTaskInput{Title: "Rolled back"}},
+		{Type: domain.BulkOperationDelete, ID: task.ID},
+		{Type: domain.BulkOperationDelete, ID: 999},
+	}, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if results[i].Err == nil || results[i].Err.Error() != "batch aborted" || results[i].Task != nil {
+			t.Errorf("Expected operation %d to be aborted, got %+v", i, results[i])
+		}
+	}
+	if results[2].Err == nil || results[2].Err.Error() != "task not found" {
+		t.Errorf("Expected task not found error, got %v", results[2].Err)
+	}
+
+	_, total, _ := service.GetTasks(ctx, 1, 10, domain.TaskFilter{})
+	if total != 1 {
+		t.Errorf("Expected 1 task, got %d", total)
+	}
+	if kept, err := service.GetTaskByID(ctx, task.ID); err != nil || kept.Version != 1 {
+		t.Errorf("Expected task to be untouched, got %v, %v", kept, err)
+	}
+
+	// Invalid operations abort the batch before anything is written.
+	results, _ = service.BulkTasks(ctx, []domain.BulkTaskOperation{
+		{Type: domain.BulkOperationDelete, ID: task.ID},
+		{Type: domain.BulkOperationCreate, Create: domain.CreateTaskInput{Title: ""}},
+	}, true)
+	if results[0].Err == nil || results[0].Err.Error() != "batch aborted" {
+		t.Errorf("Expected batch aborted error, got %v", results[0].Err)
+	}
+	if results[1].Err == nil || results[1].Err.Error() != "invalid task data" {
+		t.Errorf("Expected invalid task data error, got %v", results[1].Err)
+	}
+	if _, err := service.GetTaskByID(ctx, task.ID); err != nil {
+		t.Errorf("Expected task to remain, got %v", err)
+	}
+}
+
+func TestBulkTasksRejectsRepeatedTask(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	task, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Task"})
+
+	title := "Renamed"
+	results, _ := service.BulkTasks(ctx, []domain.BulkTaskOperation{
+		{Type: domain.BulkOperationUpdate, ID: task.ID, Update: domain.UpdateTaskInput{Title: &title}},
+		{Type: domain.BulkOperationDelete, ID: task.ID},
+	}, false)
+	if results[0].Err != nil {
+		t.Errorf("Expected no error, got %v", results[0].Err)
+	}
+	if results[1].Err == nil || results[1].Err.Error() != "task appears more than once in batch" {
+		t.Errorf("Expected task appears more than once in batch error, got %v", results[1].Err)
+	}
+
+	if _, err := service.BulkTasks(ctx, nil, true); err == nil || err.Error() != "no operations" {
+		t.Errorf("Expected no operations error, got %v", err)
+	}
+	tooMany := make([]domain.BulkTaskOperation, domain.MaxBulkOperations+1)
+	if _, err := service.BulkTasks(ctx, tooMany, true); err == nil || err.Error() != "too many operations" {
+		t.Errorf("Expected too many operations error, got %v", err)
+	}
+}
+
+func TestBulkTasksChecksHierarchyAgainstEarlierChanges(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	first, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "First"})
+	second, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Second"})
+
+	// Each move is fine against the tree before the batch, but together
+	// they make the two tasks each other's parent.
+	results, err := service.BulkTasks(ctx, []domain.BulkTaskOperation{
+		{Type: domain.BulkOperationUpdate, ID: first.ID, Update: domain.UpdateTaskInput{ParentID: &second.ID}},
+		{Type: domain.BulkOperationUpdate, ID: second.ID, Update: domain.UpdateTaskInput{ParentID: &first.ID}},
+	}, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if results[0].Err != nil {
+		t.Errorf("Expected first move to succeed, got %v", results[0].Err)
+	}
+	if results[1].Err == nil || results[1].Err.Error() != "task hierarchy cannot contain cycles" {
+		t.Errorf("Expected cycle error, got %v", results[1].Err)
+	}
+	if task, _ := service.GetTaskByID(ctx, second.ID); task.ParentID != nil {
+		t.Errorf("Expected second task to stay top level, got parent %d", *task.ParentID)
+	}
+
+	// A task deleted earlier in the batch cannot take new subtasks.
+	third, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Third"})
+	results, _ = service.BulkTasks(ctx, []domain.BulkTaskOperation{
+		{Type: domain.BulkOperationDelete, ID: second.ID},
+		{Type: domain.BulkOperationUpdate, ID: third.ID, Update: domain.UpdateTaskInput{ParentID: &second.ID}},
+		{Type: domain.BulkOperationCreate, Create: domain.CreateTaskInput{Title: "Fourth", ParentID: &second.ID}},
+	}, false)
+	for i := 1; i < 3; i++ {
+		if results[i].Err == nil || results[i].Err.Error() != "parent task not found" {
+			t.Errorf("Expected parent task not found error for operation %d, got %v", i, results[i].Err)
+		}
+	}
+}
+
+func TestUpdateTasksChangesStatusOfAll(t *testing.T) {
+	repo := NewMockTaskRepository()
+	service := NewTaskService(repo, NewMockTaskEventRepository(repo), NewMockUserRepository(), domain.DefaultWorkflow())
+	ctx := context.Background()
+
+	first, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "First"})
+	second, _ := service.CreateTask(ctx, domain.CreateTaskInput{Title: "Second"})
+
+	inProgress := domain.StatusInProgress
+	results, err := service.UpdateTasks(ctx, []uint{first.ID, second.ID}, domain.UpdateTaskInput{Status: &inProgress}, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for i, result := range results {
+		if result.Err != nil || result.Task.Status != domain.StatusInProgress {
+			t.Errorf("Expected task %d to be started, got %+v", i, result)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/logger"
+)
+
+// RunTrashPurger permanently deletes tasks that have been in the trash longer
+// than retention, checking every interval until ctx is cancelled. Each run
+// also removes the attachments of tasks purged since the previous run.
+func RunTrashPurger(ctx context.Context, taskService domain.TaskService, attachmentService domain.AttachmentService, interval, retention time.Duration) {
+	log := logger.GetLogger()
+	log.Info("Trash purger started", "interval", interval, "retention", retention)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Trash purger stopped")
+			return
+		case <-ticker.C:
+			if _, err := taskService.PurgeExpiredTasks(ctx, retention); err != nil {
+				log.Error("Failed to purge expired tasks", "error", err)
+			}
+			if _, err := attachmentService.PurgeOrphanedAttachments(ctx); err != nil {
+				log.Error("Failed to purge orphaned attachments", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/logger"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const minPasswordLength = 8
+
+// dummyPasswordHash is compared against when a username does not exist so
+// that failed logins take the same time whether or not the user exists.
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
+type UserServiceImpl struct {
+	userRepo domain.UserRepository
+}
+
+func NewUserService(userRepo domain.UserRepository) domain.UserService {
+	return &UserServiceImpl{userRepo: userRepo}
+}
+
+func (s *UserServiceImpl) CreateUser(ctx context.Context, input domain.CreateUserInput) (*domain.User, error) {
+	log := logger.GetLogger()
+	log.Info("Creating user", "username", input.Username, "role", input.Role)
+
+	role := input.Role
+	if role == "" {
+		role = domain.RoleEditor
+	}
+
+	user := &domain.User{
+		Username: strings.ToLower(strings.TrimSpace(input.Username)),
+		Role:     role,
+	}
+
+	if !domain.IsValidRole(user.Role) {
+		log.Error("Invalid role", "role", input.Role)
+		return nil, domain.NewValidationError("invalid role")
+	}
+
+	if !user.IsValid() {
+		log.Error("Invalid username", "username", input.Username)
+		return nil, domain.NewValidationError("invalid username")
+	}
+
+	if len(input.Password) < minPasswordLength {
+		log.Error("Password too short", "username", user.Username)
+		return nil, domain.NewValidationError("password must be at least 8 characters")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+	if err != nil {
+		log.Error("Failed to hash password", "error", err, "username", user.Username)
+		return nil, err
+	}
+	user.PasswordHash = string(hash)
+
+	err = s.userRepo.Create(ctx, user)
+	if err != nil {
+		log.Error("Failed to create user", "error", err, "username", user.Username)
+		return nil, err
+	}
+
+	log.Info("User created successfully", "id", user.ID, "username", user.Username)
+	return user, nil
+}
+
+func (s *UserServiceImpl) GetUserByID(ctx context.Context, id uint) (*domain.User, error) {
+	log := logger.GetLogger()
+	log.Info("Getting user by ID", "id", id)
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		log.Error("Failed to get user by ID", "error", err, "id", id)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *UserServiceImpl) GetUsers(ctx context.Context, page, limit int) ([]domain.User, int64, error) {
+	log := logger.GetLogger()
+	log.Info("Getting users", "page", page, "limit", limit)
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	users, total, err := s.userRepo.FindAll(ctx, page, limit)
+	if err != nil {
+		log.Error("Failed to get users", "error", err, "page", page, "limit", limit)
+		return nil, 0, err
+	}
+
+	log.Info("Users retrieved successfully", "count", len(users), "total", total)
+	return users, total, nil
+}
+
+func (s *UserServiceImpl) SetUserDisabled(ctx context.Context, id uint, disabled bool) (*domain.User, error) {
+	log := logger.GetLogger()
+	log.Info("Updating user status", "id", id, "disabled", disabled)
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		log.Error("Failed to find user for status update", "error", err, "id", id)
+		return nil, err
+	}
+
+	if current, ok := domain.UserFromContext(ctx); ok && current.ID == user.ID && disabled {
+		log.Error("User attempted to disable themselves", "id", id)
+		return nil, domain.NewValidationError("cannot disable your own account")
+	}
+
+	user.Disabled = disabled
+	err = s.userRepo.Update(ctx, user)
+	if err != nil {
+		log.Error("Failed to update user status", "error", err, "id", id)
+		return nil, err
+	}
+
+	log.Info("User status updated successfully", "id", id, "disabled", disabled)
+	return user, nil
+}
+
+func (s *UserServiceImpl) SetUserRole(ctx context.Context, id uint, role domain.Role) (*domain.User, error) {
+	log := logger.GetLogger()
+	log.Info("Updating user role", "id", id, "role", role)
+
+	if !domain.IsValidRole(role) {
+		log.Error("Invalid role", "role", role, "id", id)
+		return nil, domain.NewValidationError("invalid role")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		log.Error("Failed to find user for role update", "error", err, "id", id)
+		return nil, err
+	}
+
+	if current, ok := domain.UserFromContext(ctx); ok && current.ID == user.ID && role != domain.RoleAdmin {
+		log.Error("Admin attempted to demote themselves", "id", id)
+		return nil, domain.NewValidationError("cannot change your own role")
+	}
+
+	user.Role = role
+	err = s.userRepo.Update(ctx, user)
+	if err != nil {
+		log.Error("Failed to update user role", "error", err, "id", id)
+		return nil, err
+	}
+
+	log.Info("User role updated successfully", "id", id, "role", role)
+	return user, nil
+}
+
+func (s *UserServiceImpl) Authenticate(ctx context.Context, username, password string) (*domain.User, error) {
+	log := logger.GetLogger()
+
+	user, err := s.userRepo.FindByUsername(ctx, strings.ToLower(username))
+	if err != nil {
+		if !errors.Is(err, domain.ErrNotFound) {
+			log.Error("Failed to look up user", "error", err, "username", username)
+			return nil, err
+		}
+		bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+		log.Info("Authentication failed", "username", username, "reason", "unknown user")
+		return nil, domain.NewUnauthenticatedError("invalid credentials")
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		log.Info("Authentication failed", "username", username, "reason", "wrong password")
+		return nil, domain.NewUnauthenticatedError("invalid credentials")
+	}
+
+	if user.Disabled {
+		log.Info("Authentication failed", "username", username, "reason", "disabled")
+		return nil, domain.NewUnauthenticatedError("user is disabled")
+	}
+
+	return user, nil
+}
+
+// sampleAdminPassword is the password of the initial admin in the sample
+// configuration. It is public, so no account is ever created with it.
+const sampleAdminPassword = "password123"
+
+// EnsureAdmin creates the initial admin account when no users exist yet, so a
+// fresh deployment can be administered with the configured credentials. It
+// refuses to create an admin without a password or with the sample one.
+func (s *UserServiceImpl) EnsureAdmin(ctx context.Context, username, password string) error {
+	log := logger.GetLogger()
+
+	count, err := s.userRepo.Count(ctx)
+	if err != nil {
+		log.Error("Failed to count users", "error", err)
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	if password == "" || password == sampleAdminPassword {
+		log.Warn("No users found, but the initial admin password is unset or the sample default; not creating an admin", "username", username)
+		return nil
+	}
+
+	log.Info("No users found, creating initial admin", "username", username)
+	_, err = s.CreateUser(ctx, domain.CreateUserInput{Username: username, Password: password, Role: domain.RoleAdmin})
+	return err
+}
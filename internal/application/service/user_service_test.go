@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-be/internal/domain"
+)
+
+type MockUserRepository struct {
+	users  map[uint]*domain.User
+	nextID uint
+}
+
+func NewMockUserRepository() *MockUserRepository {
+	return &MockUserRepository{
+		users:  make(map[uint]*domain.User),
+		nextID: 1,
+	}
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, user *domain.User) error {
+	for _, existing := range m.users {
+		if existing.Username == user.Username {
+			return domain.NewConflictError("username already exists")
+		}
+	}
+	user.ID = m.nextID
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+	m.users[user.ID] = user
+	m.nextID++
+	return nil
+}
+
+func (m *MockUserRepository) FindByID(ctx context.Context, id uint) (*domain.User, error) {
+	user, exists := m.users[id]
+	if !exists {
+		return nil, domain.NewNotFoundError("user not found")
+	}
+	return user, nil
+}
+
+func (m *MockUserRepository) FindByUsername(ctx context.Context, username string) (*domain.User, error) {
+	for _, user := range m.users {
+		if user.Username == username {
+			return user, nil
+		}
+	}
+	return nil, domain.NewNotFoundError("user not found")
+}
+
+func (m *MockUserRepository) FindAll(ctx context.Context, page, limit int) ([]domain.User, int64, error) {
+	var users []domain.User
+	for _, user := range m.users {
+		users = append(users, *user)
+	}
+	return users, int64(len(users)), nil
+}
+
+func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) error {
+	if _, exists := m.users[user.ID]; !exists {
+		return domain.NewNotFoundError("user not found")
+	}
+	user.UpdatedAt = time.Now()
+	m.users[user.ID] = user
+	return nil
+}
+
+func (m *MockUserRepository) Count(ctx context.Context) (int64, error) {
+	return int64(len(m.users)), nil
+}
+
+func TestCreateUserHashesPassword(t *testing.T) {
+	repo := NewMockUserRepository()
+	service := NewUserService(repo)
+	ctx := context.Background()
+
+	user, err := service.CreateUser(ctx, domain.CreateUserInput{Username: "Alice", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if user.Username != "alice" {
+		t.Errorf("Expected normalised username 'alice', got %s", user.Username)
+	}
+
+	if user.PasswordHash == "" || user.PasswordHash == "correct horse" {
+		t.Error("Expected password to be stored as a hash")
+	}
+
+	if user.Role != domain.RoleEditor {
+		t.Errorf("Expected default role %s, got %s", domain.RoleEditor, user.Role)
+	}
+}
+
+func TestCreateUserRejectsUnknownRole(t *testing.T) {
+	repo := NewMockUserRepository()
+	service := NewUserService(repo)
+	ctx := context.Background()
+
+	_, err := service.CreateUser(ctx, domain.CreateUserInput{Username: "alice", Password: "correct horse", Role: "owner"})
+	if err == nil || err.Error() != "invalid role" {
+		t.Errorf("Expected invalid role error, got %v", err)
+	}
+}
+
+func TestCreateUserRejectsShortPassword(t *testing.T) {
+	repo := NewMockUserRepository()
+	service := NewUserService(repo)
+	ctx := context.Background()
+
+	_, err := service.CreateUser(ctx, domain.CreateUserInput{Username: "alice", Password: "short"})
+	if err == nil {
+		t.Error("Expected error for short password")
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	repo := NewMockUserRepository()
+	service := NewUserService(repo)
+	ctx := context.Background()
+
+	created, _ := service.CreateUser(ctx, domain.CreateUserInput{Username: "alice", Password: "correct horse"})
+
+	user, err := service.Authenticate(ctx, "alice", "correct horse")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if user.ID != created.ID {
+		t.Errorf("Expected user %d, got %d", created.ID, user.ID)
+	}
+
+	if _, err := service.Authenticate(ctx, "alice", "wrong password"); err == nil {
+		t.Error("Expected error for wrong password")
+	}
+
+	if _, err := service.Authenticate(ctx, "bob", "correct horse"); err == nil {
+		t.Error("Expected error for unknown user")
+	}
+}
+
+func TestAuthenticateDisabledUser(t *testing.T) {
+	repo := NewMockUserRepository()
+	service := NewUserService(repo)
+	ctx := context.Background()
+
+	created, _ := service.CreateUser(ctx, domain.CreateUserInput{Username: "alice", Password: "correct horse"})
+	if _, err := service.SetUserDisabled(ctx, created.ID, true); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := service.Authenticate(ctx, "alice", "correct horse"); err == nil {
+		t.Error("Expected error for disabled user")
+	}
+}
+
+func TestEnsureAdminOnlyBootstrapsEmptyDatabase(t *testing.T) {
+	repo := NewMockUserRepository()
+	service := NewUserService(repo)
+	ctx := context.Background()
+
+	if err := service.EnsureAdmin(ctx, "admin", "bootstrap secret"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	admin, err := service.Authenticate(ctx, "admin", "bootstrap secret")
+	if err != nil {
+		t.Fatalf("Expected bootstrap admin to authenticate, got %v", err)
+	}
+
+	if admin.Role != domain.RoleAdmin {
+		t.Error("Expected bootstrap user to be an admin")
+	}
+
+	if err := service.EnsureAdmin(ctx, "other", "password456"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if count, _ := repo.Count(ctx); count != 1 {
+		t.Errorf("Expected bootstrap to be skipped once users exist, got %d users", count)
+	}
+}
+
+func TestEnsureAdminRejectsMissingOrSamplePassword(t *testing.T) {
+	repo := NewMockUserRepository()
+	service := NewUserService(repo)
+	ctx := context.Background()
+
+	for _, password := range []string{"", "password123"} {
+		if err := service.EnsureAdmin(ctx, "admin", password); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if count, _ := repo.Count(ctx); count != 0 {
+		t.Errorf("Expected no admin without a real password, got %d users", count)
+	}
+}
+
+func TestSetUserRole(t *testing.T) {
+	repo := NewMockUserRepository()
+	service := NewUserService(repo)
+	ctx := context.Background()
+
+	admin, _ := service.CreateUser(ctx, domain.CreateUserInput{Username: "admin", Password: "password123", Role: domain.RoleAdmin})
+	alice, _ := service.CreateUser(ctx, domain.CreateUserInput{Username: "alice", Password: "correct horse"})
+	ctx = domain.ContextWithUser(ctx, admin)
+
+	updated, err := service.SetUserRole(ctx, alice.ID, domain.RoleViewer)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.Role != domain.RoleViewer {
+		t.Errorf("Expected role %s, got %s", domain.RoleViewer, updated.Role)
+	}
+
+	if _, err := service.SetUserRole(ctx, admin.ID, domain.RoleEditor); err == nil {
+		t.Error("Expected error when admin demotes themselves")
+	}
+}
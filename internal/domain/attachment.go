@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Attachment is a file uploaded to a task. The content lives in a BlobStore
+// under StorageKey; Checksum is the hex SHA-256 of the content and is
+// verified on download.
+type Attachment struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	TaskID      uint      `json:"task_id" gorm:"not null;index"`
+	UploadedBy  uint      `json:"uploaded_by" gorm:"not null"`
+	Filename    string    `json:"filename" gorm:"size:255;not null"`
+	ContentType string    `json:"content_type" gorm:"size:255;not null"`
+	Size        int64     `json:"size" gorm:"not null"`
+	Checksum    string    `json:"checksum" gorm:"size:64;not null"`
+	StorageKey  string    `json:"-" gorm:"size:255;not null;uniqueIndex"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// BlobStore keeps attachment content outside the database.
+type BlobStore interface {
+	// Put stores size bytes read from r under key.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the content stored under key; it returns "blob not found"
+	// when there is none.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the content stored under key. Deleting a missing key
+	// is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// MatchesContentType reports whether contentType is covered by one of the
+// allowed patterns, which are either exact types or "type/*" wildcards.
+func MatchesContentType(contentType string, allowed []string) bool {
+	contentType = strings.ToLower(contentType)
+	for _, pattern := range allowed {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "*/*" || pattern == contentType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok && strings.HasPrefix(contentType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// CleanFilename strips directories and control characters from an uploaded
+// file name so it is safe to echo back in a Content-Disposition header.
+func CleanFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" || name == ".." {
+		return "attachment"
+	}
+	for len(name) > 255 {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	return name
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "testing"
+
+func TestMatchesContentType(t *testing.T) {
+	allowed := []string{"image/*", "text/plain"}
+
+	tests := map[string]bool{
+		"image/png":       true,
+		"IMAGE/JPEG":      true,
+		"text/plain":      true,
+		"text/html":       false,
+		"imagefoo/bar":    false,
+		"application/pdf": false,
+	}
+	for contentType, expected := range tests {
+		if got := MatchesContentType(contentType, allowed); got != expected {
+			t.Errorf("Expected %s to be allowed=%v, got %v", contentType, expected, got)
+		}
+	}
+}
+
+func TestCleanFilename(t *testing.T) {
+	tests := map[string]string{
+		"screenshot.png":        "screenshot.png",
+		"../../etc/passwd":      "passwd",
+		`C:\Users\me\trace.log`: "trace.log",
+		"evil\"\r\nname.txt":    "evilname.txt",
+		"":                      "attachment",
+		"..":                    "attachment",
+	}
+	for name, expected := range tests {
+		if got := CleanFilename(name); got != expected {
+			t.Errorf("Expected %q for %q, got %q", expected, name, got)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type RefreshToken struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	UserID       uint       `json:"user_id" gorm:"not null;index"`
+	TokenHash    string     `json:"-" gorm:"uniqueIndex;not null"`
+	FamilyID     string     `json:"family_id" gorm:"not null;index"`
+	ExpiresAt    time.Time  `json:"expires_at" gorm:"not null"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	ReplacedByID *uint      `json:"replaced_by_id"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+func (t *RefreshToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+type AccessTokenClaims struct {
+	UserID    uint
+	Username  string
+	ExpiresAt time.Time
+}
+
+type TokenPair struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+type TokenIssuer interface {
+	IssueAccessToken(user *User) (string, time.Time, error)
+	ParseAccessToken(token string) (*AccessTokenClaims, error)
+}
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *RefreshToken) error
+	FindByHash(ctx context.Context, tokenHash string) (*RefreshToken, error)
+	Rotate(ctx context.Context, current *RefreshToken, next *RefreshToken) error
+	RevokeFamily(ctx context.Context, familyID string) error
+}
+
+type AuthService interface {
+	Login(ctx context.Context, username, password string) (*TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (*TokenPair, error)
+	Logout(ctx context.Context, refreshToken string) error
+	AuthenticateAccessToken(ctx context.Context, accessToken string) (*User, error)
+}
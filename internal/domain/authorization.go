@@ -0,0 +1,62 @@
+package domain
+
+import "context"
+
+type Role string
+
+const (
+	// RoleAnonymous is never stored on a user; it is the policy entry that
+	// applies to requests without credentials.
+	RoleAnonymous Role = "anonymous"
+	RoleViewer    Role = "viewer"
+	RoleEditor    Role = "editor"
+	RoleAdmin     Role = "admin"
+)
+
+func IsValidRole(role Role) bool {
+	return role == RoleViewer || role == RoleEditor || role == RoleAdmin
+}
+
+type Permission string
+
+const (
+	PermissionTaskRead        Permission = "task:read"
+	PermissionTaskHistoryRead Permission = "task:history:read"
+	PermissionTaskCreate      Permission = "task:create"
+	PermissionTaskUpdate      Permission = "task:update"
+	PermissionTaskDelete      Permission = "task:delete"
+	PermissionTaskPurge       Permission = "task:purge"
+	PermissionCommentCreate   Permission = "comment:create"
+	// PermissionCommentModerate allows editing and deleting comments written
+	// by someone else.
+	PermissionCommentModerate Permission = "comment:moderate"
+	PermissionLabelManage     Permission = "label:manage"
+	PermissionProjectManage   Permission = "project:manage"
+	PermissionUserManage      Permission = "user:manage"
+)
+
+type Policy map[Role][]Permission
+
+// DefaultPolicy lets anyone read tasks, viewers also read history, editors
+// create and update tasks and labels and comment on tasks, and only admins
+// delete, purge, moderate comments and manage projects and users.
+func DefaultPolicy() Policy {
+	return Policy{
+		RoleAnonymous: {PermissionTaskRead},
+		RoleViewer:    {PermissionTaskRead, PermissionTaskHistoryRead},
+		RoleEditor: {
+			PermissionTaskRead, PermissionTaskHistoryRead, PermissionTaskCreate, PermissionTaskUpdate,
+			PermissionCommentCreate, PermissionLabelManage,
+		},
+		RoleAdmin: {
+			PermissionTaskRead, PermissionTaskHistoryRead, PermissionTaskCreate, PermissionTaskUpdate,
+			PermissionCommentCreate, PermissionCommentModerate, PermissionLabelManage, PermissionTaskDelete,
+			PermissionTaskPurge, PermissionProjectManage, PermissionUserManage,
+		},
+	}
+}
+
+type Authorizer interface {
+	Can(user *User, permission Permission) bool
+	Authorize(ctx context.Context, permission Permission) error
+}
@@ -0,0 +1,42 @@
+package domain
+
+// MaxBulkOperations bounds the number of operations in one bulk request.
+const MaxBulkOperations = 100
+
+type BulkOperationType string
+
+const (
+	BulkOperationCreate BulkOperationType = "create"
+	BulkOperationUpdate BulkOperationType = "update"
+	BulkOperationDelete BulkOperationType = "delete"
+)
+
+// BulkTaskOperation is one operation of a bulk request. A create uses
+// Create; an update uses ID and Update, whose Version guards the write; a
+// delete moves the task with ID to the trash, at Version if it is set.
+type BulkTaskOperation struct {
+	Type    BulkOperationType
+	ID      uint
+	Create  CreateTaskInput
+	Update  UpdateTaskInput
+	Version *uint
+}
+
+// BulkTaskResult is the outcome of one operation: the created or updated
+// task, nothing for a delete, or the error that made it fail. When an
+// atomic batch fails, operations that were rolled back because of another
+// one fail with "batch aborted".
+type BulkTaskResult struct {
+	Task *Task
+	Err  error
+}
+
+// TaskChange is one write of a batch as the repository applies it: a task to
+// create, a task to save, or the ID of a task to move to the trash along with
+// the version it must be at.
+type TaskChange struct {
+	Type    BulkOperationType
+	Task    *Task
+	ID      uint
+	Version *uint
+}
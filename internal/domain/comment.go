@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxCommentLength is the longest comment body accepted, in characters.
+const MaxCommentLength = 10000
+
+// Comment is a Markdown message in the discussion thread of a task. The body
+// is stored as written; rendering is left to clients.
+type Comment struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	TaskID    uint       `json:"task_id" gorm:"not null;index"`
+	AuthorID  uint       `json:"author_id" gorm:"not null"`
+	Body      string     `json:"body" gorm:"type:text;not null"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	EditedAt  *time.Time `json:"edited_at"`
+}
+
+func (c *Comment) IsValid() bool {
+	return strings.TrimSpace(c.Body) != "" && utf8.RuneCountInString(c.Body) <= MaxCommentLength
+}
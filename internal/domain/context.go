@@ -0,0 +1,25 @@
+package domain
+
+import "context"
+
+const SystemActor = "system"
+
+type userContextKey struct{}
+
+func ContextWithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+func UserFromContext(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(userContextKey{}).(*User)
+	return user, ok && user != nil
+}
+
+// ActorFromContext returns who is performing the current operation, falling
+// back to SystemActor for background jobs and unauthenticated calls.
+func ActorFromContext(ctx context.Context) string {
+	if user, ok := UserFromContext(ctx); ok {
+		return user.Username
+	}
+	return SystemActor
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// TaskDependency records that BlockerID blocks BlockedID: the blocked task
+// should not start until the blocker is DONE.
+type TaskDependency struct {
+	BlockerID uint      `json:"blocker_id" gorm:"primaryKey;autoIncrement:false"`
+	BlockedID uint      `json:"blocked_id" gorm:"primaryKey;autoIncrement:false"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TaskDependencies struct {
+	BlockedBy []Task
+	Blocks    []Task
+}
@@ -0,0 +1,78 @@
+package domain
+
+import "errors"
+
+// Error kinds. Every error meant for the client wraps one of them, so callers
+// tell failures apart with errors.Is rather than by their message.
+var (
+	ErrValidation        = errors.New("validation failed")
+	ErrNotFound          = errors.New("not found")
+	ErrConflict          = errors.New("conflict")
+	ErrInvalidTransition = errors.New("invalid status transition")
+	// ErrUnprocessable marks a well-formed request that refers to something
+	// that cannot be used, such as a missing parent task or a disabled
+	// assignee.
+	ErrUnprocessable   = errors.New("unprocessable")
+	ErrUnauthenticated = errors.New("unauthenticated")
+	ErrForbidden       = errors.New("forbidden")
+)
+
+// Errors that callers need to recognise individually.
+var (
+	ErrAuthenticationRequired = &Error{Kind: ErrUnauthenticated, Message: "authentication required"}
+	ErrPermissionDenied       = &Error{Kind: ErrForbidden, Message: "permission denied"}
+	// ErrVersionMismatch reports a write based on a stale version of a task.
+	ErrVersionMismatch = &Error{Kind: ErrConflict, Message: "task version mismatch"}
+	// ErrBatchAborted fails the operations of an atomic batch that were
+	// rolled back because another operation failed.
+	ErrBatchAborted                = &Error{Kind: ErrConflict, Message: "batch aborted"}
+	ErrAttachmentTooLarge          = &Error{Kind: ErrValidation, Message: "attachment too large"}
+	ErrAttachmentTypeNotAllowed    = &Error{Kind: ErrValidation, Message: "attachment type not allowed"}
+	ErrIdempotencyKeyReused        = &Error{Kind: ErrUnprocessable, Message: "idempotency key reused"}
+	ErrIdempotentRequestInProgress = &Error{Kind: ErrConflict, Message: "idempotent request in progress"}
+)
+
+// Error is a failure the client can act on. Message says what went wrong and
+// Kind which sort of failure it is.
+type Error struct {
+	Kind    error
+	Message string
+	// Fields lists the offending fields of a validation error.
+	Fields []FieldError
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+// FieldError explains why one field of a request is invalid. Code is a
+// machine-readable reason such as "required" or "too_long".
+type FieldError struct {
+	Field   string
+	Code    string
+	Message string
+}
+
+func NewValidationError(message string, fields ...FieldError) error {
+	return &Error{Kind: ErrValidation, Message: message, Fields: fields}
+}
+
+func NewNotFoundError(message string) error {
+	return &Error{Kind: ErrNotFound, Message: message}
+}
+
+func NewConflictError(message string) error {
+	return &Error{Kind: ErrConflict, Message: message}
+}
+
+func NewUnprocessableError(message string) error {
+	return &Error{Kind: ErrUnprocessable, Message: message}
+}
+
+func NewUnauthenticatedError(message string) error {
+	return &Error{Kind: ErrUnauthenticated, Message: message}
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorKinds(t *testing.T) {
+	err := fmt.Errorf("loading task: %w", NewNotFoundError("task not found"))
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected wrapped error to be of kind not found, got %v", err)
+	}
+	if errors.Is(err, ErrConflict) {
+		t.Errorf("Expected not found error not to be a conflict")
+	}
+
+	var domainErr *Error
+	if !errors.As(err, &domainErr) || domainErr.Message != "task not found" {
+		t.Errorf("Expected message task not found, got %v", domainErr)
+	}
+
+	if !errors.Is(ErrVersionMismatch, ErrConflict) {
+		t.Errorf("Expected version mismatch to be a conflict")
+	}
+
+	transitionErr := &TransitionError{From: StatusToDo, To: StatusDone}
+	if !errors.Is(transitionErr, ErrInvalidTransition) {
+		t.Errorf("Expected transition error to be of kind invalid transition")
+	}
+}
+
+func TestValidationErrorFields(t *testing.T) {
+	cursor := &TaskCursor{ID: 1, Sort: "title"}
+	_, err := cursor.SortValues([]TaskSort{{Field: SortByPriority}})
+
+	var domainErr *Error
+	if !errors.As(err, &domainErr) || !errors.Is(err, ErrValidation) {
+		t.Fatalf("Expected validation error, got %v", err)
+	}
+	if len(domainErr.Fields) != 1 || domainErr.Fields[0].Field != "cursor" {
+		t.Errorf("Expected cursor field error, got %+v", domainErr.Fields)
+	}
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// MaxIdempotencyKeyLength bounds the Idempotency-Key header.
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyRecord remembers a request sent with an Idempotency-Key and,
+// once it has completed, the response to replay when the request is retried.
+// Keys are scoped to the caller, so two clients can never collide.
+type IdempotencyRecord struct {
+	Scope string `gorm:"primaryKey;size:64"`
+	Key   string `gorm:"primaryKey;column:idempotency_key;size:255"`
+	// Fingerprint is a hash of the method, URI and body of the request.
+	Fingerprint string `gorm:"size:64;not null"`
+	// StatusCode is 0 while the original request is still being processed.
+	StatusCode int            `gorm:"not null;default:0"`
+	Header     ResponseHeader `gorm:"type:jsonb"`
+	Body       []byte
+	CreatedAt  time.Time
+	ExpiresAt  time.Time `gorm:"not null;index"`
+}
+
+func (r *IdempotencyRecord) IsComplete() bool {
+	return r.StatusCode != 0
+}
+
+// ResponseHeader holds the response headers replayed with a stored response.
+type ResponseHeader map[string]string
+
+func (h ResponseHeader) Value() (driver.Value, error) {
+	if h == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (h *ResponseHeader) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*h = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, h)
+	case string:
+		return json.Unmarshal([]byte(v), h)
+	default:
+		return errors.New("unsupported type for response header")
+	}
+}
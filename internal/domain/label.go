@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"regexp"
+	"time"
+)
+
+type LabelMatchMode string
+
+const (
+	// LabelMatchAny selects tasks carrying at least one of the labels.
+	LabelMatchAny LabelMatchMode = "any"
+	// LabelMatchAll selects tasks carrying every one of the labels.
+	LabelMatchAll LabelMatchMode = "all"
+)
+
+const DefaultLabelColor = "#808080"
+
+var (
+	labelNamePattern  = regexp.MustCompile(`^[a-z0-9][a-z0-9 ._:/-]{0,49}$`)
+	labelColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+type Label struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"size:50;not null;uniqueIndex"`
+	Color     string    `json:"color" gorm:"size:7;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (l *Label) IsValid() bool {
+	return labelNamePattern.MatchString(l.Name) && labelColorPattern.MatchString(l.Color)
+}
+
+func IsValidLabelMatchMode(mode LabelMatchMode) bool {
+	return mode == LabelMatchAny || mode == LabelMatchAll
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+// TaskCursor marks a position in a task listing: the values of the sort
+// keys and the ID of a task. Clients receive it as an opaque string.
+type TaskCursor struct {
+	// Sort is the spec of the order the cursor was issued for.
+	Sort   string            `json:"s"`
+	Values []json.RawMessage `json:"v"`
+	ID     uint              `json:"i"`
+	// Backward selects the tasks before the position instead of after it.
+	Backward bool `json:"b,omitempty"`
+}
+
+// CursorAfter returns the cursor for the tasks listed after task in sort
+// order.
+func CursorAfter(task *Task, sort []TaskSort) *TaskCursor {
+	cursor := &TaskCursor{Sort: FormatTaskSort(sort), ID: task.ID}
+	for _, key := range sort {
+		value, _ := json.Marshal(key.ValueOf(task))
+		cursor.Values = append(cursor.Values, value)
+	}
+	return cursor
+}
+
+// CursorBefore returns the cursor for the tasks listed before task in sort
+// order.
+func CursorBefore(task *Task, sort []TaskSort) *TaskCursor {
+	cursor := CursorAfter(task, sort)
+	cursor.Backward = true
+	return cursor
+}
+
+func (c *TaskCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeTaskCursor(s string) (*TaskCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, NewValidationError("invalid cursor")
+	}
+	var cursor TaskCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == 0 {
+		return nil, NewValidationError("invalid cursor")
+	}
+	return &cursor, nil
+}
+
+// SortValues returns the sort key values of the cursor, typed like
+// TaskSort.ValueOf but with a missing due date as an untyped nil. It fails
+// when the cursor was issued for a different order.
+func (c *TaskCursor) SortValues(sort []TaskSort) ([]any, error) {
+	if c.Sort != FormatTaskSort(sort) || len(c.Values) != len(sort) {
+		return nil, NewValidationError("invalid cursor", FieldError{Field: "cursor", Message: "was issued for a different sort order"})
+	}
+	values := make([]any, len(sort))
+	for i, key := range sort {
+		var err error
+		switch key.Field {
+		case SortByPriority:
+			var rank int
+			err = json.Unmarshal(c.Values[i], &rank)
+			values[i] = rank
+		case SortByCreatedAt, SortByUpdatedAt:
+			var t time.Time
+			err = json.Unmarshal(c.Values[i], &t)
+			values[i] = t
+		case SortByDueAt:
+			var t *time.Time
+			err = json.Unmarshal(c.Values[i], &t)
+			if t != nil {
+				values[i] = *t
+			}
+		default:
+			var title string
+			err = json.Unmarshal(c.Values[i], &title)
+			values[i] = title
+		}
+		if err != nil {
+			return nil, NewValidationError("invalid cursor")
+		}
+	}
+	return values, nil
+}
+
+type TaskPageRequest struct {
+	Cursor *TaskCursor
+	Limit  int
+	// IncludeTotal asks for the number of matching tasks, which costs an
+	// extra COUNT query.
+	IncludeTotal bool
+}
+
+// TaskPage is one page of a cursor-paginated listing. Next and Prev are nil
+// at the ends of the listing; Total is nil unless it was requested.
+type TaskPage struct {
+	Tasks []Task
+	Limit int
+	Next  *TaskCursor
+	Prev  *TaskCursor
+	Total *int64
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	projectKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]{1,9}$`)
+	taskKeyPattern    = regexp.MustCompile(`^([A-Z][A-Z0-9]{1,9})-([1-9][0-9]*)$`)
+)
+
+type Project struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	Key         string `json:"key" gorm:"size:10;not null;uniqueIndex"`
+	Name        string `json:"name" gorm:"size:255;not null"`
+	Description string `json:"description" gorm:"type:text"`
+	Archived    bool   `json:"archived" gorm:"not null;default:false"`
+	// TaskCounter is the number of the last task created in the project.
+	TaskCounter uint      `json:"-" gorm:"not null;default:0"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (p *Project) IsValid() bool {
+	return projectKeyPattern.MatchString(p.Key) && len(p.Name) > 0 && len(p.Name) <= 255
+}
+
+// TaskKey returns the human-readable key of the project's task number n,
+// e.g. OPS-42.
+func (p *Project) TaskKey(n uint) string {
+	return fmt.Sprintf("%s-%d", p.Key, n)
+}
+
+// ParseTaskKey reports whether s looks like a task key such as OPS-42 and
+// returns it normalised to upper case.
+func ParseTaskKey(s string) (string, bool) {
+	key := strings.ToUpper(s)
+	match := taskKeyPattern.FindStringSubmatch(key)
+	if match == nil {
+		return "", false
+	}
+	if _, err := strconv.ParseUint(match[2], 10, 32); err != nil {
+		return "", false
+	}
+	return key, true
+}
@@ -1,11 +1,129 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+type TaskFilter struct {
+	// Statuses selects tasks in any of the listed statuses.
+	Statuses      []TaskStatus
+	Priority      *TaskPriority
+	DueBefore     *time.Time
+	DueAfter      *time.Time
+	CreatedBefore *time.Time
+	CreatedAfter  *time.Time
+	UpdatedBefore *time.Time
+	UpdatedAfter  *time.Time
+	Overdue       *bool
+	// TitleContains matches a case-insensitive substring of the title.
+	TitleContains string
+	// AssigneeID and CreatedBy narrow the list to one user's tasks;
+	// Unassigned selects tasks without an assignee.
+	AssigneeID *uint
+	Unassigned bool
+	CreatedBy  *uint
+	ProjectID  *uint
+	// ParentID lists the children of a task; TopLevel lists tasks without
+	// a parent.
+	ParentID *uint
+	TopLevel bool
+	// Labels holds label names, matched according to LabelMode.
+	Labels    []string
+	LabelMode LabelMatchMode
+	// Deleted lists trashed tasks instead of active ones.
+	Deleted bool
+	// Sort orders the listing; empty means DefaultTaskSort. Search results
+	// are ordered by relevance instead.
+	Sort []TaskSort
+}
 
 type TaskRepository interface {
 	Create(ctx context.Context, task *Task) error
 	FindByID(ctx context.Context, id uint) (*Task, error)
-	FindAll(ctx context.Context, page, limit int, status *TaskStatus) ([]Task, int64, error)
+	FindByKey(ctx context.Context, key string) (*Task, error)
+	FindAll(ctx context.Context, page, limit int, filter TaskFilter) ([]Task, int64, error)
+	FindPage(ctx context.Context, filter TaskFilter, request TaskPageRequest) (*TaskPage, error)
+	// Search returns the tasks matching a full-text query, most relevant
+	// first, narrowed by filter.
+	Search(ctx context.Context, query string, page, limit int, filter TaskFilter) ([]TaskSearchResult, int64, error)
+	// Update fails with "task version mismatch" when the task was written
+	// since task was read, and increments task.Version otherwise. A new
+	// parent is checked in the same transaction: it must exist and must not
+	// turn the hierarchy into a cycle.
 	Update(ctx context.Context, task *Task) error
+	// Delete fails with "task version mismatch" unless version is nil or
+	// the current version.
+	Delete(ctx context.Context, id uint, version *uint) error
+	Restore(ctx context.Context, id uint) (*Task, error)
+	Purge(ctx context.Context, id uint, version *uint) error
+	PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+	// AddDependency records that blockerID blocks blockedID and fails when
+	// the edge would close a cycle.
+	AddDependency(ctx context.Context, blockedID, blockerID uint) error
+	RemoveDependency(ctx context.Context, blockedID, blockerID uint) error
+	FindDependencies(ctx context.Context, id uint) (*TaskDependencies, error)
+	AddLabel(ctx context.Context, taskID, labelID uint) (*Task, error)
+	RemoveLabel(ctx context.Context, taskID, labelID uint) (*Task, error)
+	// ApplyBatch applies changes in a single transaction and returns the
+	// error of each change, nil for those that succeeded. When atomic, the
+	// first failure rolls back the whole batch and the remaining changes are
+	// not attempted; otherwise a failed change is rolled back on its own.
+	ApplyBatch(ctx context.Context, changes []TaskChange, atomic bool) ([]error, error)
+}
+
+type ProjectRepository interface {
+	Create(ctx context.Context, project *Project) error
+	FindByID(ctx context.Context, id uint) (*Project, error)
+	FindAll(ctx context.Context, page, limit int, includeArchived bool) ([]Project, int64, error)
+	Update(ctx context.Context, project *Project) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type LabelRepository interface {
+	Create(ctx context.Context, label *Label) error
+	FindByID(ctx context.Context, id uint) (*Label, error)
+	FindAll(ctx context.Context) ([]Label, error)
+	Update(ctx context.Context, label *Label) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type CommentRepository interface {
+	Create(ctx context.Context, comment *Comment) error
+	FindByID(ctx context.Context, id uint) (*Comment, error)
+	FindByTaskID(ctx context.Context, taskID uint, page, limit int) ([]Comment, int64, error)
+	Update(ctx context.Context, comment *Comment) error
 	Delete(ctx context.Context, id uint) error
 }
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *Attachment) error
+	FindByID(ctx context.Context, id uint) (*Attachment, error)
+	FindByTaskID(ctx context.Context, taskID uint) ([]Attachment, error)
+	// FindOrphaned returns attachments whose task has been purged.
+	FindOrphaned(ctx context.Context, limit int) ([]Attachment, error)
+	Delete(ctx context.Context, id uint) error
+}
+
+type TaskEventRepository interface {
+	FindByTaskID(ctx context.Context, taskID uint, page, limit int) ([]TaskEvent, int64, error)
+}
+
+type UserRepository interface {
+	Create(ctx context.Context, user *User) error
+	FindByID(ctx context.Context, id uint) (*User, error)
+	FindByUsername(ctx context.Context, username string) (*User, error)
+	FindAll(ctx context.Context, page, limit int) ([]User, int64, error)
+	Update(ctx context.Context, user *User) error
+	Count(ctx context.Context) (int64, error)
+}
+
+type IdempotencyRepository interface {
+	// Reserve inserts record unless a live record exists for its scope and
+	// key, which is returned instead. Records that expired, or that were
+	// left in flight since before staleBefore, are replaced.
+	Reserve(ctx context.Context, record *IdempotencyRecord, staleBefore time.Time) (*IdempotencyRecord, error)
+	Complete(ctx context.Context, record *IdempotencyRecord) error
+	Delete(ctx context.Context, scope, key string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,21 @@
+package domain
+
+const (
+	// MaxSearchQueryLength bounds the length of full-text queries.
+	MaxSearchQueryLength = 200
+
+	// HighlightStart and HighlightStop surround matching words in search
+	// highlights. The rest of a highlight is HTML-escaped, so it is safe to
+	// render as HTML.
+	HighlightStart = "<mark>"
+	HighlightStop  = "</mark>"
+)
+
+// TaskSearchResult is a task matching a search query, with its relevance and
+// the matching parts of its title and description highlighted.
+type TaskSearchResult struct {
+	Task                 Task
+	Rank                 float64
+	TitleHighlight       string
+	DescriptionHighlight string
+}
@@ -1,11 +1,160 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"io"
+	"time"
+)
+
+type CreateTaskInput struct {
+	Title       string
+	Description string
+	Priority    TaskPriority
+	StartAt     *time.Time
+	DueAt       *time.Time
+	ParentID    *uint
+	ProjectID   *uint
+}
+
+type UpdateTaskInput struct {
+	Title        *string
+	Description  *string
+	Status       *TaskStatus
+	Priority     *TaskPriority
+	StartAt      *time.Time
+	DueAt        *time.Time
+	ClearStartAt bool
+	ClearDueAt   bool
+	ParentID     *uint
+	ClearParent  bool
+	// Force moves a parent to DONE even though some children are still
+	// open, and a blocked task to IN_PROGRESS before its blockers are done.
+	Force bool
+	// Version, when set, must be the current version of the task, so an
+	// update based on a stale read fails instead of overwriting.
+	Version *uint
+}
+
+type CreateUserInput struct {
+	Username string
+	Password string
+	Role     Role
+}
+
+type ProjectInput struct {
+	Key         string
+	Name        string
+	Description string
+}
+
+type UpdateProjectInput struct {
+	Name        *string
+	Description *string
+	Archived    *bool
+}
+
+type LabelInput struct {
+	Name  string
+	Color string
+}
+
+type UpdateLabelInput struct {
+	Name  *string
+	Color *string
+}
 
 type TaskService interface {
-	CreateTask(ctx context.Context, title, description string) (*Task, error)
+	CreateTask(ctx context.Context, input CreateTaskInput) (*Task, error)
 	GetTaskByID(ctx context.Context, id uint) (*Task, error)
-	GetTasks(ctx context.Context, page, limit int, status *TaskStatus) ([]Task, int64, error)
-	UpdateTask(ctx context.Context, id uint, title, description *string, status *TaskStatus) (*Task, error)
-	DeleteTask(ctx context.Context, id uint) error
+	GetTaskByKey(ctx context.Context, key string) (*Task, error)
+	GetTasks(ctx context.Context, page, limit int, filter TaskFilter) ([]Task, int64, error)
+	GetTaskPage(ctx context.Context, filter TaskFilter, request TaskPageRequest) (*TaskPage, error)
+	SearchTasks(ctx context.Context, query string, page, limit int, filter TaskFilter) ([]TaskSearchResult, int64, error)
+	UpdateTask(ctx context.Context, id uint, input UpdateTaskInput) (*Task, error)
+	AssignTask(ctx context.Context, id uint, assigneeID uint) (*Task, error)
+	UnassignTask(ctx context.Context, id uint) (*Task, error)
+	AddTaskDependency(ctx context.Context, id uint, blockerID uint) (*Task, error)
+	RemoveTaskDependency(ctx context.Context, id uint, blockerID uint) (*Task, error)
+	GetTaskDependencies(ctx context.Context, id uint) (*TaskDependencies, error)
+	AddTaskLabel(ctx context.Context, id uint, labelID uint) (*Task, error)
+	RemoveTaskLabel(ctx context.Context, id uint, labelID uint) (*Task, error)
+	GetAllowedTransitions(ctx context.Context, id uint) (*Task, []TaskStatus, error)
+	DeleteTask(ctx context.Context, id uint, version *uint) error
+	RestoreTask(ctx context.Context, id uint) (*Task, error)
+	PurgeTask(ctx context.Context, id uint, version *uint) error
+	PurgeExpiredTasks(ctx context.Context, retention time.Duration) (int64, error)
+	GetTaskHistory(ctx context.Context, id uint, page, limit int) ([]TaskEvent, int64, error)
+	// BulkTasks runs operations in a single transaction and returns one
+	// result per operation. When atomic, either all of them succeed or none
+	// is applied.
+	BulkTasks(ctx context.Context, operations []BulkTaskOperation, atomic bool) ([]BulkTaskResult, error)
+	// UpdateTasks applies the same update to each task in ids, like
+	// BulkTasks.
+	UpdateTasks(ctx context.Context, ids []uint, input UpdateTaskInput, atomic bool) ([]BulkTaskResult, error)
+}
+
+type UserService interface {
+	CreateUser(ctx context.Context, input CreateUserInput) (*User, error)
+	GetUserByID(ctx context.Context, id uint) (*User, error)
+	GetUsers(ctx context.Context, page, limit int) ([]User, int64, error)
+	SetUserDisabled(ctx context.Context, id uint, disabled bool) (*User, error)
+	SetUserRole(ctx context.Context, id uint, role Role) (*User, error)
+	Authenticate(ctx context.Context, username, password string) (*User, error)
+	EnsureAdmin(ctx context.Context, username, password string) error
+}
+
+type LabelService interface {
+	CreateLabel(ctx context.Context, input LabelInput) (*Label, error)
+	GetLabelByID(ctx context.Context, id uint) (*Label, error)
+	GetLabels(ctx context.Context) ([]Label, error)
+	UpdateLabel(ctx context.Context, id uint, input UpdateLabelInput) (*Label, error)
+	DeleteLabel(ctx context.Context, id uint) error
+}
+
+type CommentService interface {
+	CreateComment(ctx context.Context, taskID uint, body string) (*Comment, error)
+	GetComments(ctx context.Context, taskID uint, page, limit int) ([]Comment, int64, error)
+	UpdateComment(ctx context.Context, taskID, commentID uint, body string) (*Comment, error)
+	DeleteComment(ctx context.Context, taskID, commentID uint) error
+}
+
+// UploadAttachmentInput describes an uploaded file. Size is the length
+// declared by the client; the content is counted again while it is stored.
+type UploadAttachmentInput struct {
+	Filename    string
+	ContentType string
+	Size        int64
+	Content     io.Reader
+}
+
+type AttachmentService interface {
+	UploadAttachment(ctx context.Context, taskID uint, input UploadAttachmentInput) (*Attachment, error)
+	GetAttachments(ctx context.Context, taskID uint) ([]Attachment, error)
+	// OpenAttachment returns the attachment and its content. Reading the
+	// content fails at the end if it does not match the stored checksum.
+	OpenAttachment(ctx context.Context, taskID, attachmentID uint) (*Attachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, taskID, attachmentID uint) error
+	PurgeOrphanedAttachments(ctx context.Context) (int, error)
+}
+
+type ProjectService interface {
+	CreateProject(ctx context.Context, input ProjectInput) (*Project, error)
+	GetProjectByID(ctx context.Context, id uint) (*Project, error)
+	GetProjects(ctx context.Context, page, limit int, includeArchived bool) ([]Project, int64, error)
+	UpdateProject(ctx context.Context, id uint, input UpdateProjectInput) (*Project, error)
+	DeleteProject(ctx context.Context, id uint) error
+}
+
+// IdempotencyService guards requests sent with an Idempotency-Key.
+type IdempotencyService interface {
+	// Begin reserves key for a request with the given fingerprint and
+	// returns nil, or returns the stored record of a completed request to
+	// replay. It fails with "idempotency key reused" when the key belongs to
+	// a different request and "idempotent request in progress" while the
+	// original is still being processed.
+	Begin(ctx context.Context, scope, key, fingerprint string) (*IdempotencyRecord, error)
+	Complete(ctx context.Context, scope, key string, statusCode int, header ResponseHeader, body []byte) error
+	// Abandon releases a reserved key so the request can be retried.
+	Abandon(ctx context.Context, scope, key string) error
+	PurgeExpiredKeys(ctx context.Context) (int64, error)
 }
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"strings"
 	"time"
 )
 
@@ -12,19 +13,91 @@ const (
 	StatusDone       TaskStatus = "DONE"
 )
 
+type TaskPriority string
+
+const (
+	PriorityLow    TaskPriority = "LOW"
+	PriorityMedium TaskPriority = "MEDIUM"
+	PriorityHigh   TaskPriority = "HIGH"
+	PriorityUrgent TaskPriority = "URGENT"
+)
+
 type Task struct {
-	ID          uint       `json:"id" gorm:"primaryKey"`
-	Title       string     `json:"title" gorm:"size:255;not null"`
-	Description string     `json:"description" gorm:"type:text"`
-	Status      TaskStatus `json:"status" gorm:"default:'TO_DO'"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID          uint         `json:"id" gorm:"primaryKey"`
+	Title       string       `json:"title" gorm:"size:255;not null"`
+	Description string       `json:"description" gorm:"type:text"`
+	Status      TaskStatus   `json:"status" gorm:"default:'TO_DO'"`
+	Priority    TaskPriority `json:"priority" gorm:"default:'MEDIUM'"`
+	StartAt     *time.Time   `json:"start_at"`
+	DueAt       *time.Time   `json:"due_at"`
+	CreatedBy   *uint        `json:"created_by" gorm:"index"`
+	AssigneeID  *uint        `json:"assignee_id" gorm:"index"`
+	ParentID    *uint        `json:"parent_id" gorm:"index"`
+	ProjectID   *uint        `json:"project_id" gorm:"index"`
+	// Key is assigned on creation for tasks in a project, e.g. OPS-42.
+	Key *string `json:"key" gorm:"size:32;uniqueIndex"`
+	// Version is incremented on every write and serves as the task's ETag.
+	Version   uint      `json:"version" gorm:"not null;default:1"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// DeletedAt is set while the task is in the trash.
+	DeletedAt *time.Time `json:"deleted_at" gorm:"index"`
+	Labels    []Label    `json:"labels" gorm:"many2many:task_labels"`
+
+	IsOverdue    bool         `json:"is_overdue" gorm:"-"`
+	IsBlocked    bool         `json:"blocked" gorm:"-"`
+	Progress     TaskProgress `json:"progress" gorm:"-"`
+	CommentCount int64        `json:"comment_count" gorm:"-"`
+}
+
+// TaskProgress rolls up the status of a task's direct children.
+type TaskProgress struct {
+	Done  int64 `json:"done"`
+	Total int64 `json:"total"`
+}
+
+func (p TaskProgress) OpenChildren() int64 {
+	return p.Total - p.Done
+}
+
+// NormalizeTaskTitle trims a title and collapses every run of whitespace,
+// line breaks included, into a single space.
+func NormalizeTaskTitle(title string) string {
+	return strings.Join(strings.Fields(title), " ")
 }
 
 func (t *Task) IsValid() bool {
-	return len(t.Title) > 0 && len(t.Title) <= 255
+	return len(t.Title) > 0 && len(t.Title) <= 255 && t.IsValidPriority(t.Priority) && t.HasValidSchedule()
+}
+
+func (t *Task) HasValidSchedule() bool {
+	return t.StartAt == nil || t.DueAt == nil || t.StartAt.Before(*t.DueAt)
+}
+
+func (t *Task) IsOverdueAt(now time.Time) bool {
+	return t.DueAt != nil && t.Status != StatusDone && t.DueAt.Before(now)
 }
 
 func (t *Task) IsValidStatus(status TaskStatus) bool {
 	return status == StatusToDo || status == StatusInProgress || status == StatusDone
 }
+
+func (t *Task) IsValidPriority(priority TaskPriority) bool {
+	return priority == PriorityLow || priority == PriorityMedium || priority == PriorityHigh || priority == PriorityUrgent
+}
+
+// PriorityRank orders priorities from least to most urgent. It mirrors the
+// generated priority_rank column that listings are sorted by.
+func PriorityRank(priority TaskPriority) int {
+	switch priority {
+	case PriorityUrgent:
+		return 4
+	case PriorityHigh:
+		return 3
+	case PriorityMedium:
+		return 2
+	case PriorityLow:
+		return 1
+	}
+	return 0
+}
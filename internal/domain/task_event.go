@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+)
+
+type TaskEventType string
+
+const (
+	TaskEventCreated  TaskEventType = "CREATED"
+	TaskEventUpdated  TaskEventType = "UPDATED"
+	TaskEventDeleted  TaskEventType = "DELETED"
+	TaskEventRestored TaskEventType = "RESTORED"
+	TaskEventPurged   TaskEventType = "PURGED"
+)
+
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+type FieldChanges []FieldChange
+
+func (c FieldChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (c *FieldChanges) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return errors.New("unsupported type for field changes")
+	}
+}
+
+type TaskEvent struct {
+	ID        uint          `json:"id" gorm:"primaryKey"`
+	TaskID    uint          `json:"task_id" gorm:"not null;index"`
+	Type      TaskEventType `json:"type" gorm:"not null"`
+	Actor     string        `json:"actor" gorm:"not null"`
+	Changes   FieldChanges  `json:"changes" gorm:"type:jsonb;not null"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// DiffTasks lists the audited fields that differ between before and after.
+// A nil before describes a creation and a nil after a deletion.
+func DiffTasks(before, after *Task) FieldChanges {
+	var empty Task
+	if before == nil {
+		before = &empty
+	}
+	if after == nil {
+		after = &empty
+	}
+
+	fields := []struct {
+		name          string
+		before, after any
+	}{
+		{"title", before.Title, after.Title},
+		{"description", before.Description, after.Description},
+		{"status", before.Status, after.Status},
+		{"priority", before.Priority, after.Priority},
+		{"start_at", before.StartAt, after.StartAt},
+		{"due_at", before.DueAt, after.DueAt},
+		{"assignee_id", before.AssigneeID, after.AssigneeID},
+		{"parent_id", before.ParentID, after.ParentID},
+		{"project_id", before.ProjectID, after.ProjectID},
+	}
+
+	changes := FieldChanges{}
+	for _, f := range fields {
+		if equalFieldValues(f.before, f.after) {
+			continue
+		}
+		changes = append(changes, FieldChange{
+			Field:  f.name,
+			Before: auditValue(f.before),
+			After:  auditValue(f.after),
+		})
+	}
+	return changes
+}
+
+func equalFieldValues(a, b any) bool {
+	if ta, ok := a.(*time.Time); ok {
+		tb := b.(*time.Time)
+		if ta == nil || tb == nil {
+			return ta == nil && tb == nil
+		}
+		return ta.Equal(*tb)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// auditValue normalises zero values to nil so created/deleted events read as
+// "from nothing" / "to nothing" rather than from an empty string.
+func auditValue(v any) any {
+	if t, ok := v.(*time.Time); ok {
+		if t == nil {
+			return nil
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	if reflect.ValueOf(v).IsZero() {
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffTasksReportsChangedFields(t *testing.T) {
+	due := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+	before := &Task{Title: "Old", Status: StatusToDo, Priority: PriorityMedium}
+	after := &Task{Title: "New", Status: StatusToDo, Priority: PriorityMedium, DueAt: &due}
+
+	changes := DiffTasks(before, after)
+
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %d: %+v", len(changes), changes)
+	}
+	if changes[0].Field != "title" || changes[0].Before != "Old" || changes[0].After != "New" {
+		t.Errorf("Unexpected title change %+v", changes[0])
+	}
+	if changes[1].Field != "due_at" || changes[1].Before != nil || changes[1].After != "2024-01-02T15:00:00Z" {
+		t.Errorf("Unexpected due_at change %+v", changes[1])
+	}
+}
+
+func TestDiffTasksIgnoresEqualTimes(t *testing.T) {
+	due := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+	sameDue := due.In(time.FixedZone("UTC+7", 7*60*60))
+
+	changes := DiffTasks(&Task{Title: "Task", DueAt: &due}, &Task{Title: "Task", DueAt: &sameDue})
+	if len(changes) != 0 {
+		t.Errorf("Expected no changes, got %+v", changes)
+	}
+}
+
+func TestDiffTasksOnCreation(t *testing.T) {
+	changes := DiffTasks(nil, &Task{Title: "Task", Status: StatusToDo})
+
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %d: %+v", len(changes), changes)
+	}
+	for _, change := range changes {
+		if change.Before != nil {
+			t.Errorf("Expected nil before value for %s, got %v", change.Field, change.Before)
+		}
+	}
+}
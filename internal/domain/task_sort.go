@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"strings"
+)
+
+// TaskSortField names a field task listings can be ordered by. Only the
+// fields below are accepted, so a sort spec can never reach arbitrary
+// columns.
+type TaskSortField string
+
+const (
+	SortByPriority  TaskSortField = "priority"
+	SortByCreatedAt TaskSortField = "created_at"
+	SortByUpdatedAt TaskSortField = "updated_at"
+	SortByDueAt     TaskSortField = "due_at"
+	SortByTitle     TaskSortField = "title"
+)
+
+func IsValidTaskSortField(field TaskSortField) bool {
+	return field == SortByPriority || field == SortByCreatedAt || field == SortByUpdatedAt ||
+		field == SortByDueAt || field == SortByTitle
+}
+
+// TaskSort is one key of a listing order. Ties left by all keys are broken
+// by task ID in the direction of the last key.
+type TaskSort struct {
+	Field      TaskSortField
+	Descending bool
+}
+
+// DefaultTaskSort lists tasks by priority (URGENT first), then newest first.
+func DefaultTaskSort() []TaskSort {
+	return []TaskSort{
+		{Field: SortByPriority, Descending: true},
+		{Field: SortByCreatedAt, Descending: true},
+	}
+}
+
+// ParseTaskSort parses a comma-separated sort spec such as
+// "-priority,created_at", where a leading minus sorts descending.
+func ParseTaskSort(spec string) ([]TaskSort, error) {
+	var sort []TaskSort
+	seen := make(map[TaskSortField]bool)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		key := TaskSort{Field: TaskSortField(strings.TrimPrefix(part, "-")), Descending: strings.HasPrefix(part, "-")}
+		if key.Field == "" {
+			return nil, NewValidationError("empty sort field")
+		}
+		if !IsValidTaskSortField(key.Field) {
+			return nil, NewValidationError("unknown sort field " + string(key.Field))
+		}
+		if seen[key.Field] {
+			return nil, NewValidationError("duplicate sort field " + string(key.Field))
+		}
+		seen[key.Field] = true
+		sort = append(sort, key)
+	}
+	return sort, nil
+}
+
+// FormatTaskSort returns the spec ParseTaskSort reads sort from.
+func FormatTaskSort(sort []TaskSort) string {
+	parts := make([]string, len(sort))
+	for i, key := range sort {
+		parts[i] = string(key.Field)
+		if key.Descending {
+			parts[i] = "-" + parts[i]
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// ValueOf returns the value task is ordered by for this key. Priorities
+// order by PriorityRank; a missing due date is nil and sorts after every
+// date.
+func (s TaskSort) ValueOf(task *Task) any {
+	switch s.Field {
+	case SortByPriority:
+		return PriorityRank(task.Priority)
+	case SortByCreatedAt:
+		return task.CreatedAt
+	case SortByUpdatedAt:
+		return task.UpdatedAt
+	case SortByDueAt:
+		return task.DueAt
+	default:
+		return task.Title
+	}
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseTaskSort(t *testing.T) {
+	sort, err := ParseTaskSort("-priority, created_at")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []TaskSort{{Field: SortByPriority, Descending: true}, {Field: SortByCreatedAt}}
+	if !reflect.DeepEqual(sort, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, sort)
+	}
+	if got := FormatTaskSort(sort); got != "-priority,created_at" {
+		t.Errorf("Expected -priority,created_at, got %s", got)
+	}
+
+	invalid := map[string]string{
+		"password":           "unknown sort field password",
+		"title,-title":       "duplicate sort field title",
+		"title,":             "empty sort field",
+		"priority_rank DESC": "unknown sort field priority_rank DESC",
+	}
+	for spec, message := range invalid {
+		if _, err := ParseTaskSort(spec); err == nil || err.Error() != message {
+			t.Errorf("Expected error %q for %q, got %v", message, spec, err)
+		}
+	}
+}
+
+func TestTaskCursorSortValues(t *testing.T) {
+	due := time.Date(2023, 11, 1, 17, 0, 0, 0, time.UTC)
+	task := &Task{ID: 7, Title: "Write docs", Priority: PriorityHigh, DueAt: &due}
+	sort := []TaskSort{{Field: SortByDueAt}, {Field: SortByTitle, Descending: true}, {Field: SortByPriority}}
+
+	cursor, err := DecodeTaskCursor(CursorAfter(task, sort).Encode())
+	if err != nil {
+		t.Fatalf("Expected cursor to round-trip, got %v", err)
+	}
+	values, err := cursor.SortValues(sort)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := []any{due, "Write docs", PriorityRank(PriorityHigh)}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("Expected %v, got %v", expected, values)
+	}
+
+	task.DueAt = nil
+	values, _ = CursorAfter(task, sort).SortValues(sort)
+	if values[0] != nil {
+		t.Errorf("Expected nil for a missing due date, got %v", values[0])
+	}
+
+	if _, err := cursor.SortValues(DefaultTaskSort()); err == nil {
+		t.Error("Expected error for a cursor issued for another order")
+	}
+}
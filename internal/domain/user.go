@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"regexp"
+	"time"
+)
+
+var usernamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{2,63}$`)
+
+type User struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	Username     string    `json:"username" gorm:"size:64;uniqueIndex;not null"`
+	PasswordHash string    `json:"-" gorm:"not null"`
+	Role         Role      `json:"role" gorm:"not null;default:'editor'"`
+	Disabled     bool      `json:"disabled" gorm:"not null;default:false"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (u *User) IsValid() bool {
+	return usernamePattern.MatchString(u.Username) && IsValidRole(u.Role)
+}
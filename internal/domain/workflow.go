@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Workflow struct {
+	transitions map[TaskStatus][]TaskStatus
+}
+
+type TransitionError struct {
+	From    TaskStatus
+	To      TaskStatus
+	Allowed []TaskStatus
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("invalid status transition from %s to %s", e.From, e.To)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// DefaultWorkflow moves tasks forward TO_DO → IN_PROGRESS → DONE, lets work in
+// progress go back to TO_DO and reopens DONE tasks into IN_PROGRESS.
+func DefaultWorkflow() *Workflow {
+	return &Workflow{
+		transitions: map[TaskStatus][]TaskStatus{
+			StatusToDo:       {StatusInProgress},
+			StatusInProgress: {StatusToDo, StatusDone},
+			StatusDone:       {StatusInProgress},
+		},
+	}
+}
+
+func NewWorkflow(transitions map[TaskStatus][]TaskStatus) (*Workflow, error) {
+	var task Task
+	for from, targets := range transitions {
+		if !task.IsValidStatus(from) {
+			return nil, fmt.Errorf("unknown status %q in workflow", from)
+		}
+		for _, to := range targets {
+			if !task.IsValidStatus(to) {
+				return nil, fmt.Errorf("unknown status %q in workflow", to)
+			}
+		}
+	}
+	return &Workflow{transitions: transitions}, nil
+}
+
+// ParseWorkflow builds a workflow from a status → "NEXT|OTHER" map, the shape
+// produced by envconfig for TASK_WORKFLOW_TRANSITIONS. An empty spec yields
+// the default workflow.
+func ParseWorkflow(spec map[string]string) (*Workflow, error) {
+	if len(spec) == 0 {
+		return DefaultWorkflow(), nil
+	}
+
+	transitions := make(map[TaskStatus][]TaskStatus, len(spec))
+	for from, targets := range spec {
+		status := TaskStatus(strings.TrimSpace(from))
+		transitions[status] = []TaskStatus{}
+		for _, to := range strings.Split(targets, "|") {
+			if to = strings.TrimSpace(to); to != "" {
+				transitions[status] = append(transitions[status], TaskStatus(to))
+			}
+		}
+	}
+	return NewWorkflow(transitions)
+}
+
+func (w *Workflow) CanTransition(from, to TaskStatus) bool {
+	if from == to {
+		return true
+	}
+	for _, allowed := range w.transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Workflow) AllowedTransitions(from TaskStatus) []TaskStatus {
+	allowed := make([]TaskStatus, len(w.transitions[from]))
+	copy(allowed, w.transitions[from])
+	return allowed
+}
+
+func (w *Workflow) Transition(from, to TaskStatus) error {
+	if !w.CanTransition(from, to) {
+		return &TransitionError{From: from, To: to, Allowed: w.AllowedTransitions(from)}
+	}
+	return nil
+}
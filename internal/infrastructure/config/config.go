@@ -1,15 +1,21 @@
 package config
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Auth     AuthConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	Auth        AuthConfig
+	JWT         JWTConfig
+	Workflow    WorkflowConfig
+	Trash       TrashConfig
+	Storage     StorageConfig
+	Idempotency IdempotencyConfig
 }
 
 type ServerConfig struct {
@@ -29,8 +35,63 @@ type DatabaseConfig struct {
 }
 
 type AuthConfig struct {
+	// Username and Password create the initial admin account on first start.
+	// Without a password no account is created.
 	Username string `envconfig:"BASIC_AUTH_USERNAME" default:"admin"`
-	Password string `envconfig:"BASIC_AUTH_PASSWORD" default:"password123"`
+	Password string `envconfig:"BASIC_AUTH_PASSWORD"`
+	// AllowAnonymousRead lets requests without credentials list and read tasks.
+	AllowAnonymousRead bool `envconfig:"AUTH_ALLOW_ANONYMOUS_READ" default:"true"`
+}
+
+// JWTConfig holds the HMAC keys used for access tokens, given as
+// JWT_SIGNING_KEYS="kid1:secret1,kid2:secret2". New tokens are signed with
+// JWT_ACTIVE_KEY_ID; every listed key is accepted when verifying.
+type JWTConfig struct {
+	SigningKeys map[string]string `envconfig:"JWT_SIGNING_KEYS"`
+	ActiveKeyID string            `envconfig:"JWT_ACTIVE_KEY_ID"`
+	// AllowEphemeralKey lets a development server start without signing
+	// keys, with a random key whose tokens do not survive a restart.
+	AllowEphemeralKey bool          `envconfig:"JWT_ALLOW_EPHEMERAL_KEY" default:"false"`
+	Issuer            string        `envconfig:"JWT_ISSUER" default:"task-be"`
+	AccessTokenTTL    time.Duration `envconfig:"JWT_ACCESS_TOKEN_TTL" default:"15m"`
+	RefreshTokenTTL   time.Duration `envconfig:"JWT_REFRESH_TOKEN_TTL" default:"720h"`
+}
+
+// WorkflowConfig overrides the default status transitions, e.g.
+// TASK_WORKFLOW_TRANSITIONS="TO_DO:IN_PROGRESS,IN_PROGRESS:TO_DO|DONE,DONE:IN_PROGRESS".
+type WorkflowConfig struct {
+	Transitions map[string]string `envconfig:"TASK_WORKFLOW_TRANSITIONS"`
+}
+
+type TrashConfig struct {
+	Retention     time.Duration `envconfig:"TRASH_RETENTION" default:"720h"`
+	PurgeInterval time.Duration `envconfig:"TRASH_PURGE_INTERVAL" default:"1h"`
+}
+
+// StorageConfig selects where attachment content is kept and which uploads
+// are accepted. STORAGE_DRIVER is "local" (files under STORAGE_LOCAL_PATH) or
+// "s3" (any S3-compatible service such as MinIO).
+type StorageConfig struct {
+	Driver    string `envconfig:"STORAGE_DRIVER" default:"local"`
+	LocalPath string `envconfig:"STORAGE_LOCAL_PATH" default:"./data/attachments"`
+
+	S3Endpoint  string `envconfig:"STORAGE_S3_ENDPOINT" default:"https://s3.amazonaws.com"`
+	S3Region    string `envconfig:"STORAGE_S3_REGION" default:"us-east-1"`
+	S3Bucket    string `envconfig:"STORAGE_S3_BUCKET"`
+	S3AccessKey string `envconfig:"STORAGE_S3_ACCESS_KEY"`
+	S3SecretKey string `envconfig:"STORAGE_S3_SECRET_KEY"`
+
+	MaxAttachmentSize int64    `envconfig:"ATTACHMENT_MAX_SIZE" default:"10485760"`
+	AllowedTypes      []string `envconfig:"ATTACHMENT_ALLOWED_TYPES" default:"image/*,text/plain,text/csv,application/pdf,application/json,application/zip,application/gzip"`
+}
+
+// IdempotencyConfig controls how long responses to requests sent with an
+// Idempotency-Key are kept for replay, and after how long a request that
+// never completed (e.g. because the server crashed) stops holding its key.
+type IdempotencyConfig struct {
+	TTL           time.Duration `envconfig:"IDEMPOTENCY_TTL" default:"24h"`
+	LockTimeout   time.Duration `envconfig:"IDEMPOTENCY_LOCK_TIMEOUT" default:"1m"`
+	PurgeInterval time.Duration `envconfig:"IDEMPOTENCY_PURGE_INTERVAL" default:"1h"`
 }
 
 func Load() (*Config, error) {
@@ -38,5 +99,22 @@ func Load() (*Config, error) {
 	if err := envconfig.Process("", &cfg); err != nil {
 		return nil, err
 	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
 	return &cfg, nil
 }
+
+// validate rejects settings that would only fail once the server is running.
+func (c *Config) validate() error {
+	if c.Trash.Retention <= 0 {
+		return fmt.Errorf("TRASH_RETENTION must be positive, got %s", c.Trash.Retention)
+	}
+	if c.Trash.PurgeInterval <= 0 {
+		return fmt.Errorf("TRASH_PURGE_INTERVAL must be positive, got %s", c.Trash.PurgeInterval)
+	}
+	if c.Idempotency.PurgeInterval <= 0 {
+		return fmt.Errorf("IDEMPOTENCY_PURGE_INTERVAL must be positive, got %s", c.Idempotency.PurgeInterval)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Expected the defaults to be valid, got %v", err)
+	}
+	if cfg.Trash.Retention <= 0 || cfg.Trash.PurgeInterval <= 0 || cfg.Idempotency.PurgeInterval <= 0 {
+		t.Errorf("Expected positive trash and idempotency durations, got %+v %+v", cfg.Trash, cfg.Idempotency)
+	}
+}
+
+func TestLoadRejectsNonPositiveDurations(t *testing.T) {
+	for _, name := range []string{"TRASH_RETENTION", "TRASH_PURGE_INTERVAL", "IDEMPOTENCY_PURGE_INTERVAL"} {
+		for _, value := range []string{"0s", "-1h"} {
+			t.Setenv(name, value)
+
+			_, err := Load()
+			if err == nil || !strings.Contains(err.Error(), name) {
+				t.Errorf("Expected %s=%s to be rejected, got %v", name, value, err)
+			}
+		}
+		t.Setenv(name, "1h")
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"gorm.io/gorm"
 	gormLogger "gorm.io/gorm/logger"
 
-	"task-be/internal/domain"
 	"task-be/internal/infrastructure/config"
 	appLogger "task-be/internal/infrastructure/logger"
 )
@@ -26,7 +25,8 @@ func NewDatabase(ctx context.Context, cfg *config.Config) *gorm.DB {
 	)
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: gormLogger.Default.LogMode(gormLogger.Info),
+		Logger:         gormLogger.Default.LogMode(gormLogger.Info),
+		TranslateError: true,
 	})
 	if err != nil {
 		log.Error("Failed to connect to database", "error", err, "host", cfg.Database.Host, "port", cfg.Database.Port, "dbname", cfg.Database.Name)
@@ -35,13 +35,5 @@ func NewDatabase(ctx context.Context, cfg *config.Config) *gorm.DB {
 
 	log.Info("Database connected successfully", "host", cfg.Database.Host, "port", cfg.Database.Port, "dbname", cfg.Database.Name)
 
-	err = db.AutoMigrate(&domain.Task{})
-	if err != nil {
-		log.Error("Failed to migrate database", "error", err)
-		panic("Failed to migrate database")
-	}
-
-	log.Info("Database migration completed successfully")
-
 	return db
 }
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS tasks (
+    id          BIGSERIAL PRIMARY KEY,
+    title       VARCHAR(255) NOT NULL,
+    description TEXT,
+    status      TEXT DEFAULT 'TO_DO',
+    created_at  TIMESTAMPTZ,
+    updated_at  TIMESTAMPTZ
+);
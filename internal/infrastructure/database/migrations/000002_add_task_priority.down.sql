@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_priority_order;
+ALTER TABLE tasks DROP COLUMN IF EXISTS priority_rank;
+ALTER TABLE tasks DROP COLUMN IF EXISTS priority;
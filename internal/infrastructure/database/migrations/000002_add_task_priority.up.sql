@@ -0,0 +1,14 @@
+ALTER TABLE tasks ADD COLUMN priority TEXT NOT NULL DEFAULT 'MEDIUM';
+
+-- Numeric rank so listings can order by urgency without a CASE per query.
+ALTER TABLE tasks ADD COLUMN priority_rank SMALLINT GENERATED ALWAYS AS (
+    CASE priority
+        WHEN 'URGENT' THEN 4
+        WHEN 'HIGH' THEN 3
+        WHEN 'MEDIUM' THEN 2
+        WHEN 'LOW' THEN 1
+        ELSE 0
+    END
+) STORED;
+
+CREATE INDEX idx_tasks_priority_order ON tasks (priority_rank DESC, created_at DESC, id DESC);
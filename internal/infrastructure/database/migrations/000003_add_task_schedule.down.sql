@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_tasks_due_at;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS chk_tasks_start_before_due;
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS start_at;
@@ -0,0 +1,7 @@
+ALTER TABLE tasks ADD COLUMN start_at TIMESTAMPTZ;
+ALTER TABLE tasks ADD COLUMN due_at TIMESTAMPTZ;
+
+ALTER TABLE tasks ADD CONSTRAINT chk_tasks_start_before_due
+    CHECK (start_at IS NULL OR due_at IS NULL OR start_at < due_at);
+
+CREATE INDEX idx_tasks_due_at ON tasks (due_at) WHERE due_at IS NOT NULL;
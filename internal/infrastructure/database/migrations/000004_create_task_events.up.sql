@@ -0,0 +1,11 @@
+-- No foreign key to tasks: the history must outlive the task it describes.
+CREATE TABLE task_events (
+    id         BIGSERIAL PRIMARY KEY,
+    task_id    BIGINT NOT NULL,
+    type       TEXT NOT NULL,
+    actor      TEXT NOT NULL,
+    changes    JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_task_events_task_id ON task_events (task_id, created_at DESC, id DESC);
@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX idx_tasks_deleted_at ON tasks (deleted_at);
@@ -0,0 +1,13 @@
+CREATE TABLE refresh_tokens (
+    id             BIGSERIAL PRIMARY KEY,
+    user_id        BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    token_hash     TEXT NOT NULL UNIQUE,
+    family_id      TEXT NOT NULL,
+    expires_at     TIMESTAMPTZ NOT NULL,
+    revoked_at     TIMESTAMPTZ,
+    replaced_by_id BIGINT REFERENCES refresh_tokens (id) ON DELETE SET NULL,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+CREATE INDEX idx_refresh_tokens_family_id ON refresh_tokens (family_id);
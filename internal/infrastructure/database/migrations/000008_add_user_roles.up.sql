@@ -0,0 +1,6 @@
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'editor'
+    CHECK (role IN ('viewer', 'editor', 'admin'));
+
+UPDATE users SET role = 'admin' WHERE is_admin;
+
+ALTER TABLE users DROP COLUMN is_admin;
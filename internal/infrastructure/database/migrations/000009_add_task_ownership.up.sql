@@ -0,0 +1,6 @@
+ALTER TABLE tasks
+    ADD COLUMN created_by  BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    ADD COLUMN assignee_id BIGINT REFERENCES users (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_tasks_created_by ON tasks (created_by);
+CREATE INDEX idx_tasks_assignee_id ON tasks (assignee_id);
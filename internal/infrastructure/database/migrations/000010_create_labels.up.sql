@@ -0,0 +1,15 @@
+CREATE TABLE labels (
+    id         BIGSERIAL PRIMARY KEY,
+    name       VARCHAR(50) NOT NULL UNIQUE,
+    color      VARCHAR(7) NOT NULL DEFAULT '#808080',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE task_labels (
+    task_id  BIGINT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    label_id BIGINT NOT NULL REFERENCES labels (id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, label_id)
+);
+
+CREATE INDEX idx_task_labels_label_id ON task_labels (label_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_tasks_parent_id;
+
+ALTER TABLE tasks
+    DROP CONSTRAINT IF EXISTS chk_tasks_parent_not_self,
+    DROP COLUMN parent_id;
@@ -0,0 +1,5 @@
+ALTER TABLE tasks
+    ADD COLUMN parent_id BIGINT REFERENCES tasks (id) ON DELETE SET NULL,
+    ADD CONSTRAINT chk_tasks_parent_not_self CHECK (parent_id <> id);
+
+CREATE INDEX idx_tasks_parent_id ON tasks (parent_id) WHERE parent_id IS NOT NULL;
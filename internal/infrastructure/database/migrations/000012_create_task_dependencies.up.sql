@@ -0,0 +1,9 @@
+CREATE TABLE task_dependencies (
+    blocker_id BIGINT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    blocked_id BIGINT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX idx_task_dependencies_blocked_id ON task_dependencies (blocked_id);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_tasks_project_id;
+
+ALTER TABLE tasks
+    DROP COLUMN key,
+    DROP COLUMN project_id;
+
+DROP TABLE IF EXISTS projects;
@@ -0,0 +1,16 @@
+CREATE TABLE projects (
+    id           BIGSERIAL PRIMARY KEY,
+    key          VARCHAR(10) NOT NULL UNIQUE,
+    name         VARCHAR(255) NOT NULL,
+    description  TEXT NOT NULL DEFAULT '',
+    archived     BOOLEAN NOT NULL DEFAULT FALSE,
+    task_counter BIGINT NOT NULL DEFAULT 0,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE tasks
+    ADD COLUMN project_id BIGINT REFERENCES projects (id) ON DELETE RESTRICT,
+    ADD COLUMN key        VARCHAR(32) UNIQUE;
+
+CREATE INDEX idx_tasks_project_id ON tasks (project_id) WHERE project_id IS NOT NULL;
@@ -0,0 +1,11 @@
+CREATE TABLE comments (
+    id         BIGSERIAL PRIMARY KEY,
+    task_id    BIGINT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    author_id  BIGINT NOT NULL REFERENCES users (id),
+    body       TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    edited_at  TIMESTAMPTZ
+);
+
+CREATE INDEX idx_comments_task_id ON comments (task_id, created_at);
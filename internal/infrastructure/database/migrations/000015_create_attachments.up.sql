@@ -0,0 +1,15 @@
+-- task_id deliberately has no foreign key: rows of purged tasks are kept
+-- until their content has been removed from the blob store.
+CREATE TABLE attachments (
+    id           BIGSERIAL PRIMARY KEY,
+    task_id      BIGINT NOT NULL,
+    uploaded_by  BIGINT NOT NULL REFERENCES users (id),
+    filename     VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size         BIGINT NOT NULL,
+    checksum     CHAR(64) NOT NULL,
+    storage_key  VARCHAR(255) NOT NULL UNIQUE,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_attachments_task_id ON attachments (task_id);
@@ -0,0 +1,8 @@
+-- Title matches rank above description matches. The text search
+-- configuration must match searchConfig in the task repository.
+ALTER TABLE tasks ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'B')
+) STORED;
+
+CREATE INDEX idx_tasks_search_vector ON tasks USING GIN (search_vector);
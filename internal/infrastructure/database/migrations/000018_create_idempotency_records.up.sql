@@ -0,0 +1,13 @@
+CREATE TABLE idempotency_records (
+    scope           VARCHAR(64)  NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    fingerprint     VARCHAR(64)  NOT NULL,
+    status_code     INTEGER      NOT NULL DEFAULT 0,
+    header          JSONB,
+    body            BYTEA,
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    expires_at      TIMESTAMPTZ  NOT NULL,
+    PRIMARY KEY (scope, idempotency_key)
+);
+
+CREATE INDEX idx_idempotency_records_expires_at ON idempotency_records (expires_at);
@@ -0,0 +1,337 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+
+	"task-be/internal/infrastructure/database/migrations"
+	"task-be/internal/infrastructure/logger"
+)
+
+// migrationLockID is the key of the Postgres advisory lock held while
+// migrations run, so concurrent replicas never apply the same script twice.
+const migrationLockID int64 = 4761503227
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+type MigrationStatus struct {
+	Version          int64
+	Name             string
+	Applied          bool
+	AppliedAt        *time.Time
+	ChecksumMismatch bool
+}
+
+type schemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	Checksum  string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	list, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: list}, nil
+}
+
+// LoadMigrations reads NNN_name.up.sql / NNN_name.down.sql pairs from fsys
+// and returns them ordered by version.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d used by both %s and %s", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	list := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", m.Version, m.Name)
+		}
+		sum := sha256.Sum256([]byte(m.Up))
+		m.Checksum = hex.EncodeToString(sum[:])
+		list = append(list, *m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+
+	return list, nil
+}
+
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		pending, err := m.pending(conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range pending {
+			if err := m.apply(conn, migration); err != nil {
+				return err
+			}
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	reverted := 0
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		for ; reverted < steps; reverted++ {
+			migration, err := m.revertLatest(conn)
+			if err != nil {
+				return err
+			}
+			if migration == nil {
+				break
+			}
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Redo reverts the latest applied migration and applies it again.
+func (m *Migrator) Redo(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *gorm.DB) error {
+		migration, err := m.revertLatest(conn)
+		if err != nil {
+			return err
+		}
+		if migration == nil {
+			return fmt.Errorf("no applied migrations to redo")
+		}
+		return m.apply(conn, *migration)
+	})
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(m.db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if row, ok := applied[migration.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.ChecksumMismatch = row.Checksum != migration.Checksum
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Verify returns an error when the database schema is behind the migrations
+// embedded in this binary or an applied migration has been modified.
+func (m *Migrator) Verify(ctx context.Context) error {
+	pending, err := m.pending(m.db.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%d pending migration(s), latest is %d_%s",
+			len(pending), pending[len(pending)-1].Version, pending[len(pending)-1].Name)
+	}
+	return nil
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	log := logger.GetLogger()
+
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		log.Info("Acquiring migration lock")
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockID).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		defer func() {
+			if err := conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockID).Error; err != nil {
+				log.Error("Failed to release migration lock", "error", err)
+			}
+		}()
+
+		if err := m.ensureTable(conn); err != nil {
+			return err
+		}
+		return fn(conn)
+	})
+}
+
+func (m *Migrator) ensureTable(conn *gorm.DB) error {
+	return conn.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    BIGINT PRIMARY KEY,
+		name       TEXT NOT NULL,
+		checksum   TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`).Error
+}
+
+// applied returns the applied migrations by version. A database without
+// the schema_migrations table has none; the table is only created by the
+// commands that change the schema.
+func (m *Migrator) applied(conn *gorm.DB) (map[int64]schemaMigration, error) {
+	var exists bool
+	if err := conn.Raw("SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists).Error; err != nil {
+		return nil, err
+	}
+	if !exists {
+		return map[int64]schemaMigration{}, nil
+	}
+	var rows []schemaMigration
+	if err := conn.Order("version").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	applied := make(map[int64]schemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+func (m *Migrator) pending(conn *gorm.DB) ([]Migration, error) {
+	applied, err := m.applied(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, migration := range m.migrations {
+		row, ok := applied[migration.Version]
+		if !ok {
+			pending = append(pending, migration)
+			continue
+		}
+		if row.Checksum != migration.Checksum {
+			return nil, fmt.Errorf("checksum mismatch for applied migration %d_%s", migration.Version, migration.Name)
+		}
+	}
+	return pending, nil
+}
+
+func (m *Migrator) apply(conn *gorm.DB, migration Migration) error {
+	log := logger.GetLogger()
+	log.Info("Applying migration", "version", migration.Version, "name", migration.Name)
+
+	err := conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Up).Error; err != nil {
+			return err
+		}
+		return tx.Create(&schemaMigration{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Checksum:  migration.Checksum,
+			AppliedAt: time.Now(),
+		}).Error
+	})
+	if err != nil {
+		log.Error("Failed to apply migration", "error", err, "version", migration.Version, "name", migration.Name)
+		return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+func (m *Migrator) revertLatest(conn *gorm.DB) (*Migration, error) {
+	log := logger.GetLogger()
+
+	var latest schemaMigration
+	result := conn.Order("version DESC").Limit(1).Find(&latest)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	var migration *Migration
+	for i := range m.migrations {
+		if m.migrations[i].Version == latest.Version {
+			migration = &m.migrations[i]
+			break
+		}
+	}
+	if migration == nil {
+		return nil, fmt.Errorf("applied migration %d_%s is unknown to this binary", latest.Version, latest.Name)
+	}
+	if migration.Down == "" {
+		return nil, fmt.Errorf("migration %d_%s has no down script", migration.Version, migration.Name)
+	}
+
+	log.Info("Reverting migration", "version", migration.Version, "name", migration.Name)
+	err := conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Down).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&schemaMigration{}, migration.Version).Error
+	})
+	if err != nil {
+		log.Error("Failed to revert migration", "error", err, "version", migration.Version, "name", migration.Name)
+		return nil, fmt.Errorf("reverting migration %d_%s failed: %w", migration.Version, migration.Name, err)
+	}
+	return migration, nil
+}
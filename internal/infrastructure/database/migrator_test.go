@@ -0,0 +1,70 @@
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"task-be/internal/infrastructure/database/migrations"
+)
+
+func TestLoadMigrationsOrdersAndPairsFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000002_add_index.up.sql":      {Data: []byte("CREATE INDEX idx ON tasks (status);")},
+		"000002_add_index.down.sql":    {Data: []byte("DROP INDEX idx;")},
+		"000001_create_tasks.up.sql":   {Data: []byte("CREATE TABLE tasks (id BIGSERIAL);")},
+		"000001_create_tasks.down.sql": {Data: []byte("DROP TABLE tasks;")},
+		"README.md":                    {Data: []byte("ignored")},
+	}
+
+	list, err := LoadMigrations(fsys)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(list) != 2 {
+		t.Fatalf("Expected 2 migrations, got %d", len(list))
+	}
+	if list[0].Version != 1 || list[0].Name != "create_tasks" {
+		t.Errorf("Expected first migration 1_create_tasks, got %d_%s", list[0].Version, list[0].Name)
+	}
+	if list[1].Down != "DROP INDEX idx;" {
+		t.Errorf("Expected down script to be paired, got %q", list[1].Down)
+	}
+	if list[0].Checksum == "" || list[0].Checksum == list[1].Checksum {
+		t.Errorf("Expected distinct checksums, got %q and %q", list[0].Checksum, list[1].Checksum)
+	}
+}
+
+func TestLoadMigrationsRejectsMissingUpScript(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000001_create_tasks.down.sql": {Data: []byte("DROP TABLE tasks;")},
+	}
+
+	if _, err := LoadMigrations(fsys); err == nil {
+		t.Error("Expected error for migration without up script")
+	}
+}
+
+func TestLoadMigrationsRejectsConflictingVersions(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000001_create_tasks.up.sql": {Data: []byte("CREATE TABLE tasks (id BIGSERIAL);")},
+		"000001_create_users.up.sql": {Data: []byte("CREATE TABLE users (id BIGSERIAL);")},
+	}
+
+	if _, err := LoadMigrations(fsys); err == nil {
+		t.Error("Expected error for duplicate migration version")
+	}
+}
+
+func TestEmbeddedMigrationsHaveDownScripts(t *testing.T) {
+	list, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		t.Fatalf("Expected embedded migrations to load, got %v", err)
+	}
+
+	for _, m := range list {
+		if m.Down == "" {
+			t.Errorf("Migration %d_%s has no down script", m.Version, m.Name)
+		}
+	}
+}
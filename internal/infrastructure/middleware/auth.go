@@ -1,20 +1,72 @@
 package middleware
 
 import (
-	"crypto/subtle"
+	"errors"
+	"strings"
 
-	"task-be/internal/infrastructure/config"
+	"task-be/internal/domain"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
 
-func BasicAuth(cfg *config.Config) echo.MiddlewareFunc {
+func BasicAuth(userService domain.UserService) echo.MiddlewareFunc {
 	return middleware.BasicAuth(func(username, password string, c echo.Context) (bool, error) {
-		if subtle.ConstantTimeCompare([]byte(username), []byte(cfg.Auth.Username)) == 1 &&
-			subtle.ConstantTimeCompare([]byte(password), []byte(cfg.Auth.Password)) == 1 {
-			return true, nil
+		user, err := userService.Authenticate(c.Request().Context(), username, password)
+		if err != nil {
+			if errors.Is(err, domain.ErrUnauthenticated) {
+				return false, nil
+			}
+			return false, err
 		}
-		return false, nil
+
+		ctx := domain.ContextWithUser(c.Request().Context(), user)
+		c.SetRequest(c.Request().WithContext(ctx))
+		return true, nil
+	})
+}
+
+func BearerAuth(authService domain.AuthService) echo.MiddlewareFunc {
+	return middleware.KeyAuthWithConfig(middleware.KeyAuthConfig{
+		KeyLookup:  "header:" + echo.HeaderAuthorization,
+		AuthScheme: "Bearer",
+		Validator: func(accessToken string, c echo.Context) (bool, error) {
+			user, err := authService.AuthenticateAccessToken(c.Request().Context(), accessToken)
+			if err != nil {
+				if errors.Is(err, domain.ErrUnauthenticated) {
+					return false, nil
+				}
+				return false, err
+			}
+
+			ctx := domain.ContextWithUser(c.Request().Context(), user)
+			c.SetRequest(c.Request().WithContext(ctx))
+			return true, nil
+		},
 	})
 }
+
+// Authenticate accepts either a bearer access token or Basic credentials,
+// depending on the scheme of the Authorization header. Requests without an
+// Authorization header continue anonymously; handlers decide what anonymous
+// callers may do through the domain.Authorizer.
+func Authenticate(userService domain.UserService, authService domain.AuthService) echo.MiddlewareFunc {
+	basic := BasicAuth(userService)
+	bearer := BearerAuth(authService)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		basicNext := basic(next)
+		bearerNext := bearer(next)
+
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+			if header == "" {
+				return next(c)
+			}
+			if len(header) > len("Bearer ") && strings.EqualFold(header[:len("Bearer ")], "Bearer ") {
+				return bearerNext(c)
+			}
+			return basicNext(c)
+		}
+	}
+}
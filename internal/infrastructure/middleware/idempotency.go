@@ -0,0 +1,211 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/logger"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	headerIdempotencyKey     = "Idempotency-Key"
+	headerIdempotentReplayed = "Idempotent-Replayed"
+	// memoryBodyLimit is the largest request body kept in memory while it is
+	// fingerprinted; larger bodies are spooled to a temporary file.
+	memoryBodyLimit = 64 << 10
+)
+
+var errBodyTooLarge = errors.New("request body too large")
+
+// replayedHeaders are stored with an idempotent response and sent again
+// when it is replayed.
+var replayedHeaders = []string{echo.HeaderContentType, echo.HeaderLocation, "ETag"}
+
+// Idempotency makes unsafe requests carrying an Idempotency-Key header safe to
+// retry. The first request with a key runs and its response is stored;
+// retries with the same key and request get that response replayed, a
+// different request with the same key is rejected with 422, and a retry
+// arriving while the first request is still running gets 409. Responses with
+// a 5xx status are not stored, so such requests can be retried for real.
+//
+// The body is hashed while it is read, so its size is bounded by maxBody
+// rather than by memory. It must run after authentication, as keys are
+// scoped to the caller.
+func Idempotency(idempotencyService domain.IdempotencyService, maxBody int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			key := req.Header.Get(headerIdempotencyKey)
+			if key == "" || req.Method == http.MethodGet || req.Method == http.MethodHead || req.Method == http.MethodOptions {
+				return next(c)
+			}
+			if len(key) > domain.MaxIdempotencyKeyLength {
+				return echo.NewHTTPError(http.StatusBadRequest, "Idempotency-Key must be at most "+strconv.Itoa(domain.MaxIdempotencyKeyLength)+" characters")
+			}
+
+			// The body is fingerprinted as it is read and handed on to the
+			// handler unchanged.
+			fingerprint := newFingerprint(req)
+			body, err := spoolBody(req.Body, fingerprint, maxBody)
+			if err != nil {
+				if errors.Is(err, errBodyTooLarge) {
+					return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "Request body too large")
+				}
+				return echo.NewHTTPError(http.StatusBadRequest, "Failed to read request body")
+			}
+			defer body.Close()
+			req.Body = body
+
+			ctx := req.Context()
+			scope := idempotencyScope(ctx)
+			record, err := idempotencyService.Begin(ctx, scope, key, hex.EncodeToString(fingerprint.Sum(nil)))
+			if err != nil {
+				if errors.Is(err, domain.ErrIdempotencyKeyReused) {
+					return echo.NewHTTPError(http.StatusUnprocessableEntity, "Idempotency-Key has already been used for a different request")
+				}
+				if errors.Is(err, domain.ErrIdempotentRequestInProgress) {
+					c.Response().Header().Set(echo.HeaderRetryAfter, "1")
+					return echo.NewHTTPError(http.StatusConflict, "A request with this Idempotency-Key is still being processed")
+				}
+				return err
+			}
+			if record != nil {
+				return replayResponse(c, record)
+			}
+
+			// The key stays reserved only once the response is stored; a
+			// failed request, or a panic, releases it.
+			completed := false
+			defer func() {
+				if !completed {
+					idempotencyService.Abandon(context.WithoutCancel(ctx), scope, key)
+				}
+			}()
+
+			recorder := &bodyRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+			if err := next(c); err != nil {
+				// Render the error now so the response can be stored.
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			if status >= http.StatusInternalServerError {
+				return nil
+			}
+			header := domain.ResponseHeader{}
+			for _, name := range replayedHeaders {
+				if value := c.Response().Header().Get(name); value != "" {
+					header[name] = value
+				}
+			}
+			err = idempotencyService.Complete(context.WithoutCancel(ctx), scope, key, status, header, recorder.body.Bytes())
+			completed = err == nil
+			return nil
+		}
+	}
+}
+
+func replayResponse(c echo.Context, record *domain.IdempotencyRecord) error {
+	log := logger.GetLogger()
+	log.Info("Replaying response for idempotent request", "key", record.Key, "status", record.StatusCode)
+
+	header := c.Response().Header()
+	for name, value := range record.Header {
+		header.Set(name, value)
+	}
+	header.Set(headerIdempotentReplayed, "true")
+	c.Response().WriteHeader(record.StatusCode)
+	_, err := c.Response().Write(record.Body)
+	return err
+}
+
+// idempotencyScope namespaces keys by caller.
+func idempotencyScope(ctx context.Context) string {
+	if user, ok := domain.UserFromContext(ctx); ok {
+		return "user:" + strconv.FormatUint(uint64(user.ID), 10)
+	}
+	return "anonymous"
+}
+
+// newFingerprint starts the hash that identifies what a request asks for,
+// so a key reused for another request is detected. The body is added as it
+// is read.
+func newFingerprint(req *http.Request) hash.Hash {
+	fingerprint := sha256.New()
+	fingerprint.Write([]byte(req.Method + " " + req.URL.RequestURI() + "\n"))
+	return fingerprint
+}
+
+// spoolBody reads body to the end, writing it to fingerprint, and returns
+// the same content for the handler. Small bodies are kept in memory, larger
+// ones in a temporary file that is removed on Close. A body longer than
+// maxBody fails with errBodyTooLarge.
+func spoolBody(body io.Reader, fingerprint io.Writer, maxBody int64) (io.ReadCloser, error) {
+	body = io.LimitReader(body, maxBody+1)
+	var head bytes.Buffer
+	n, err := io.Copy(io.MultiWriter(&head, fingerprint), io.LimitReader(body, memoryBodyLimit+1))
+	if err != nil {
+		return nil, err
+	}
+	if n > maxBody {
+		return nil, errBodyTooLarge
+	}
+	if n <= memoryBodyLimit {
+		return io.NopCloser(&head), nil
+	}
+
+	file, err := os.CreateTemp("", "idempotent-body-*")
+	if err != nil {
+		return nil, err
+	}
+	spooled := &spooledBody{File: file}
+	if _, err := head.WriteTo(file); err != nil {
+		spooled.Close()
+		return nil, err
+	}
+	rest, err := io.Copy(io.MultiWriter(file, fingerprint), body)
+	if err == nil && n+rest > maxBody {
+		err = errBodyTooLarge
+	}
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		spooled.Close()
+		return nil, err
+	}
+	return spooled, nil
+}
+
+// spooledBody is a request body spooled to a temporary file.
+type spooledBody struct {
+	*os.File
+}
+
+func (b *spooledBody) Close() error {
+	b.File.Close()
+	return os.Remove(b.Name())
+}
+
+// bodyRecorder copies the response body while it is written.
+type bodyRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,195 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"task-be/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MockIdempotencyService keeps records in memory with the semantics of the
+// real service.
+type MockIdempotencyService struct {
+	mu      sync.Mutex
+	records map[string]*domain.IdempotencyRecord
+}
+
+func NewMockIdempotencyService() *MockIdempotencyService {
+	return &MockIdempotencyService{records: make(map[string]*domain.IdempotencyRecord)}
+}
+
+func (m *MockIdempotencyService) Begin(ctx context.Context, scope, key, fingerprint string) (*domain.IdempotencyRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	existing, exists := m.records[scope+"/"+key]
+	if !exists {
+		m.records[scope+"/"+key] = &domain.IdempotencyRecord{Scope: scope, Key: key, Fingerprint: fingerprint}
+		return nil, nil
+	}
+	if existing.Fingerprint != fingerprint {
+		return nil, domain.ErrIdempotencyKeyReused
+	}
+	if !existing.IsComplete() {
+		return nil, domain.ErrIdempotentRequestInProgress
+	}
+	return existing, nil
+}
+
+func (m *MockIdempotencyService) Complete(ctx context.Context, scope, key string, statusCode int, header domain.ResponseHeader, body []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	record := m.records[scope+"/"+key]
+	record.StatusCode, record.Header, record.Body = statusCode, header, body
+	return nil
+}
+
+func (m *MockIdempotencyService) Abandon(ctx context.Context, scope, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.records, scope+"/"+key)
+	return nil
+}
+
+func (m *MockIdempotencyService) PurgeExpiredKeys(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+// newIdempotentEcho serves POST /tasks through the middleware with handler.
+func newIdempotentEcho(handler echo.HandlerFunc, maxBody int64) *echo.Echo {
+	e := echo.New()
+	e.Use(Idempotency(NewMockIdempotencyService(), maxBody))
+	e.POST("/tasks", handler)
+	return e
+}
+
+func sendIdempotent(e *echo.Echo, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(headerIdempotencyKey, key)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotencyReplaysStoredResponse(t *testing.T) {
+	calls := 0
+	e := newIdempotentEcho(func(c echo.Context) error {
+		calls++
+		body, _ := io.ReadAll(c.Request().Body)
+		c.Response().Header().Set(echo.HeaderLocation, "/tasks/1")
+		return c.String(http.StatusCreated, "created "+string(body))
+	}, 1<<20)
+
+	first := sendIdempotent(e, "key-1", `{"title":"Task"}`)
+	second := sendIdempotent(e, "key-1", `{"title":"Task"}`)
+
+	if calls != 1 {
+		t.Errorf("Expected the handler to run once, got %d", calls)
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Errorf("Expected replayed %d %q, got %d %q", first.Code, first.Body.String(), second.Code, second.Body.String())
+	}
+	if first.Body.String() != `created {"title":"Task"}` {
+		t.Errorf("Expected the handler to read the body unchanged, got %q", first.Body.String())
+	}
+	if second.Header().Get(headerIdempotentReplayed) != "true" || second.Header().Get(echo.HeaderLocation) != "/tasks/1" {
+		t.Errorf("Expected replayed headers, got %v", second.Header())
+	}
+	if first.Header().Get(headerIdempotentReplayed) != "" {
+		t.Error("Expected the original response not to be marked as replayed")
+	}
+}
+
+func TestIdempotencyRejectsKeyReusedForDifferentBody(t *testing.T) {
+	e := newIdempotentEcho(func(c echo.Context) error {
+		return c.NoContent(http.StatusCreated)
+	}, 1<<20)
+
+	sendIdempotent(e, "key-1", `{"title":"Task"}`)
+	rec := sendIdempotent(e, "key-1", `{"title":"Other"}`)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status 422, got %d", rec.Code)
+	}
+}
+
+func TestIdempotencyRejectsRetryWhileInFlight(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	e := newIdempotentEcho(func(c echo.Context) error {
+		close(started)
+		<-release
+		return c.NoContent(http.StatusCreated)
+	}, 1<<20)
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- sendIdempotent(e, "key-1", `{}`) }()
+	<-started
+
+	rec := sendIdempotent(e, "key-1", `{}`)
+	if rec.Code != http.StatusConflict {
+		t.Errorf("Expected status 409, got %d", rec.Code)
+	}
+	if rec.Header().Get(echo.HeaderRetryAfter) == "" {
+		t.Error("Expected a Retry-After header")
+	}
+
+	close(release)
+	if first := <-done; first.Code != http.StatusCreated {
+		t.Errorf("Expected the original request to complete with 201, got %d", first.Code)
+	}
+}
+
+func TestIdempotencyDoesNotStoreServerErrors(t *testing.T) {
+	calls := 0
+	e := newIdempotentEcho(func(c echo.Context) error {
+		calls++
+		if calls == 1 {
+			return echo.NewHTTPError(http.StatusServiceUnavailable, "try again")
+		}
+		return c.NoContent(http.StatusCreated)
+	}, 1<<20)
+
+	if rec := sendIdempotent(e, "key-1", `{}`); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected status 503, got %d", rec.Code)
+	}
+	rec := sendIdempotent(e, "key-1", `{}`)
+	if rec.Code != http.StatusCreated || rec.Header().Get(headerIdempotentReplayed) != "" {
+		t.Errorf("Expected the retry to run for real, got %d %v", rec.Code, rec.Header())
+	}
+	if calls != 2 {
+		t.Errorf("Expected the handler to run twice, got %d", calls)
+	}
+}
+
+func TestIdempotencyStreamsLargeBodies(t *testing.T) {
+	var received []byte
+	e := newIdempotentEcho(func(c echo.Context) error {
+		received, _ = io.ReadAll(c.Request().Body)
+		return c.NoContent(http.StatusCreated)
+	}, 4*memoryBodyLimit)
+
+	// Bodies beyond what is kept in memory are spooled to disk and reach
+	// the handler unchanged.
+	body := strings.Repeat("a", 3*memoryBodyLimit)
+	if rec := sendIdempotent(e, "key-1", body); rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d", rec.Code)
+	}
+	if !bytes.Equal(received, []byte(body)) {
+		t.Errorf("Expected the handler to read %d bytes, got %d", len(body), len(received))
+	}
+	if rec := sendIdempotent(e, "key-1", body[:len(body)-1]+"b"); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected a change at the end of a spooled body to be detected, got %d", rec.Code)
+	}
+
+	if rec := sendIdempotent(e, "key-2", strings.Repeat("a", 4*memoryBodyLimit+1)); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413, got %d", rec.Code)
+	}
+}
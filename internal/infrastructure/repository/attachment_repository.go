@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/logger"
+
+	"gorm.io/gorm"
+)
+
+type AttachmentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) domain.AttachmentRepository {
+	return &AttachmentRepositoryImpl{db: db}
+}
+
+func (r *AttachmentRepositoryImpl) Create(ctx context.Context, attachment *domain.Attachment) error {
+	log := logger.GetLogger()
+	log.Info("Creating attachment", "task_id", attachment.TaskID, "filename", attachment.Filename, "size", attachment.Size)
+	return r.db.WithContext(ctx).Create(attachment).Error
+}
+
+func (r *AttachmentRepositoryImpl) FindByID(ctx context.Context, id uint) (*domain.Attachment, error) {
+	var attachment domain.Attachment
+	err := r.db.WithContext(ctx).First(&attachment, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFoundError("attachment not found")
+		}
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *AttachmentRepositoryImpl) FindByTaskID(ctx context.Context, taskID uint) ([]domain.Attachment, error) {
+	var attachments []domain.Attachment
+	err := r.db.WithContext(ctx).Where("task_id = ?", taskID).Order("created_at, id").Find(&attachments).Error
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *AttachmentRepositoryImpl) FindOrphaned(ctx context.Context, limit int) ([]domain.Attachment, error) {
+	var attachments []domain.Attachment
+	err := r.db.WithContext(ctx).
+		Where("NOT EXISTS (SELECT 1 FROM tasks WHERE tasks.id = attachments.task_id)").
+		Order("id").
+		Limit(limit).
+		Find(&attachments).Error
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *AttachmentRepositoryImpl) Delete(ctx context.Context, id uint) error {
+	log := logger.GetLogger()
+	log.Info("Deleting attachment", "id", id)
+	result := r.db.WithContext(ctx).Delete(&domain.Attachment{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.NewNotFoundError("attachment not found")
+	}
+	return nil
+}
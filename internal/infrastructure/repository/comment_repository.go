@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/logger"
+
+	"gorm.io/gorm"
+)
+
+type CommentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) domain.CommentRepository {
+	return &CommentRepositoryImpl{db: db}
+}
+
+func (r *CommentRepositoryImpl) Create(ctx context.Context, comment *domain.Comment) error {
+	log := logger.GetLogger()
+	log.Info("Creating comment", "task_id", comment.TaskID, "author_id", comment.AuthorID)
+	return r.db.WithContext(ctx).Create(comment).Error
+}
+
+func (r *CommentRepositoryImpl) FindByID(ctx context.Context, id uint) (*domain.Comment, error) {
+	var comment domain.Comment
+	err := r.db.WithContext(ctx).First(&comment, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFoundError("comment not found")
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (r *CommentRepositoryImpl) FindByTaskID(ctx context.Context, taskID uint, page, limit int) ([]domain.Comment, int64, error) {
+	var comments []domain.Comment
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.Comment{}).Where("task_id = ?", taskID)
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err = query.Order("created_at, id").Offset(offset).Limit(limit).Find(&comments).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
+
+func (r *CommentRepositoryImpl) Update(ctx context.Context, comment *domain.Comment) error {
+	log := logger.GetLogger()
+	log.Info("Updating comment", "id", comment.ID, "task_id", comment.TaskID)
+	result := r.db.WithContext(ctx).Model(comment).
+		Select("body", "updated_at", "edited_at").
+		Updates(comment)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.NewNotFoundError("comment not found")
+	}
+	return nil
+}
+
+func (r *CommentRepositoryImpl) Delete(ctx context.Context, id uint) error {
+	log := logger.GetLogger()
+	log.Info("Deleting comment", "id", id)
+	result := r.db.WithContext(ctx).Delete(&domain.Comment{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.NewNotFoundError("comment not found")
+	}
+	return nil
+}
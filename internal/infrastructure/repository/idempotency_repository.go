@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"task-be/internal/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyRepository(db *gorm.DB) domain.IdempotencyRepository {
+	return &IdempotencyRepositoryImpl{db: db}
+}
+
+// Reserve relies on the primary key: of two requests racing for the same key
+// exactly one insert succeeds, the other waits for it to commit and then
+// finds the winner's record.
+func (r *IdempotencyRepositoryImpl) Reserve(ctx context.Context, record *domain.IdempotencyRecord, staleBefore time.Time) (*domain.IdempotencyRecord, error) {
+	var existing *domain.IdempotencyRecord
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("scope = ? AND idempotency_key = ?", record.Scope, record.Key).
+			Where("expires_at < ? OR (status_code = 0 AND created_at < ?)", record.CreatedAt, staleBefore).
+			Delete(&domain.IdempotencyRecord{}).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 1 {
+			return nil
+		}
+
+		existing = &domain.IdempotencyRecord{}
+		return tx.Where("scope = ? AND idempotency_key = ?", record.Scope, record.Key).First(existing).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (r *IdempotencyRepositoryImpl) Complete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	return r.db.WithContext(ctx).Model(&domain.IdempotencyRecord{}).
+		Where("scope = ? AND idempotency_key = ?", record.Scope, record.Key).
+		Updates(map[string]any{
+			"status_code": record.StatusCode,
+			"header":      record.Header,
+			"body":        record.Body,
+		}).Error
+}
+
+func (r *IdempotencyRepositoryImpl) Delete(ctx context.Context, scope, key string) error {
+	return r.db.WithContext(ctx).
+		Where("scope = ? AND idempotency_key = ?", scope, key).
+		Delete(&domain.IdempotencyRecord{}).Error
+}
+
+func (r *IdempotencyRepositoryImpl) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&domain.IdempotencyRecord{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/logger"
+
+	"gorm.io/gorm"
+)
+
+type LabelRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewLabelRepository(db *gorm.DB) domain.LabelRepository {
+	return &LabelRepositoryImpl{db: db}
+}
+
+func (r *LabelRepositoryImpl) Create(ctx context.Context, label *domain.Label) error {
+	log := logger.GetLogger()
+	log.Info("Creating label", "name", label.Name, "color", label.Color)
+	err := r.db.WithContext(ctx).Create(label).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domain.NewConflictError("label already exists")
+	}
+	return err
+}
+
+func (r *LabelRepositoryImpl) FindByID(ctx context.Context, id uint) (*domain.Label, error) {
+	var label domain.Label
+	err := r.db.WithContext(ctx).First(&label, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFoundError("label not found")
+		}
+		return nil, err
+	}
+	return &label, nil
+}
+
+func (r *LabelRepositoryImpl) FindAll(ctx context.Context) ([]domain.Label, error) {
+	var labels []domain.Label
+	err := r.db.WithContext(ctx).Order("name").Find(&labels).Error
+	if err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+func (r *LabelRepositoryImpl) Update(ctx context.Context, label *domain.Label) error {
+	log := logger.GetLogger()
+	log.Info("Updating label", "id", label.ID, "name", label.Name, "color", label.Color)
+	err := r.db.WithContext(ctx).Save(label).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domain.NewConflictError("label already exists")
+	}
+	return err
+}
+
+func (r *LabelRepositoryImpl) Delete(ctx context.Context, id uint) error {
+	log := logger.GetLogger()
+	log.Info("Deleting label", "id", id)
+	result := r.db.WithContext(ctx).Delete(&domain.Label{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.NewNotFoundError("label not found")
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/logger"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProjectRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewProjectRepository(db *gorm.DB) domain.ProjectRepository {
+	return &ProjectRepositoryImpl{db: db}
+}
+
+func (r *ProjectRepositoryImpl) Create(ctx context.Context, project *domain.Project) error {
+	log := logger.GetLogger()
+	log.Info("Creating project", "key", project.Key, "name", project.Name)
+	err := r.db.WithContext(ctx).Create(project).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domain.NewConflictError("project key already exists")
+	}
+	return err
+}
+
+func (r *ProjectRepositoryImpl) FindByID(ctx context.Context, id uint) (*domain.Project, error) {
+	var project domain.Project
+	err := r.db.WithContext(ctx).First(&project, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFoundError("project not found")
+		}
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (r *ProjectRepositoryImpl) FindAll(ctx context.Context, page, limit int, includeArchived bool) ([]domain.Project, int64, error) {
+	var projects []domain.Project
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.Project{})
+	if !includeArchived {
+		query = query.Where("archived = ?", false)
+	}
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err = query.Order("key").Offset(offset).Limit(limit).Find(&projects).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return projects, total, nil
+}
+
+// Update saves the editable fields only; the key and the task counter are
+// never changed after creation.
+func (r *ProjectRepositoryImpl) Update(ctx context.Context, project *domain.Project) error {
+	log := logger.GetLogger()
+	log.Info("Updating project", "id", project.ID, "name", project.Name, "archived", project.Archived)
+	return r.db.WithContext(ctx).Model(project).
+		Select("name", "description", "archived", "updated_at").
+		Updates(project).Error
+}
+
+func (r *ProjectRepositoryImpl) Delete(ctx context.Context, id uint) error {
+	log := logger.GetLogger()
+	log.Info("Deleting project", "id", id)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var project domain.Project
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&project, id).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.NewNotFoundError("project not found")
+			}
+			return err
+		}
+
+		// Trashed tasks still reference the project, so count them too.
+		var tasks int64
+		if err := tx.Model(&domain.Task{}).Where("project_id = ?", id).Count(&tasks).Error; err != nil {
+			return err
+		}
+		if tasks > 0 {
+			return domain.NewConflictError("project has tasks")
+		}
+
+		return tx.Delete(&domain.Project{}, id).Error
+	})
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"task-be/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) domain.RefreshTokenRepository {
+	return &RefreshTokenRepositoryImpl{db: db}
+}
+
+func (r *RefreshTokenRepositoryImpl) Create(ctx context.Context, token *domain.RefreshToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+func (r *RefreshTokenRepositoryImpl) FindByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFoundError("refresh token not found")
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Rotate revokes current and stores next in one transaction. The conditional
+// update makes sure a token can only be exchanged once, even when two refresh
+// requests race.
+func (r *RefreshTokenRepositoryImpl) Rotate(ctx context.Context, current *domain.RefreshToken, next *domain.RefreshToken) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&domain.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", current.ID).
+			Updates(map[string]any{"revoked_at": time.Now(), "replaced_by_id": next.ID})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.NewConflictError("refresh token already used")
+		}
+		return nil
+	})
+}
+
+func (r *RefreshTokenRepositoryImpl) RevokeFamily(ctx context.Context, familyID string) error {
+	return r.db.WithContext(ctx).Model(&domain.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"task-be/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type TaskEventRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewTaskEventRepository(db *gorm.DB) domain.TaskEventRepository {
+	return &TaskEventRepositoryImpl{db: db}
+}
+
+func (r *TaskEventRepositoryImpl) FindByTaskID(ctx context.Context, taskID uint, page, limit int) ([]domain.TaskEvent, int64, error) {
+	var events []domain.TaskEvent
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.TaskEvent{}).Where("task_id = ?", taskID)
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err = query.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&events).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
@@ -3,13 +3,27 @@ package repository
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
 	"task-be/internal/domain"
 	"task-be/internal/infrastructure/logger"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// maxHierarchyDepth bounds recursive walks over the parent chain.
+const maxHierarchyDepth = 1000
+
+// dependencyLockID is the key of the transaction-scoped advisory lock taken
+// while a dependency edge is checked for cycles and inserted.
+const dependencyLockID int64 = 4761503228
+
+// hierarchyLockID is the key of the transaction-scoped advisory lock taken
+// by every write that gives a task a new parent.
+const hierarchyLockID int64 = 4761503229
+
 type TaskRepositoryImpl struct {
 	db *gorm.DB
 }
@@ -21,29 +35,46 @@ func NewTaskRepository(db *gorm.DB) domain.TaskRepository {
 func (r *TaskRepositoryImpl) Create(ctx context.Context, task *domain.Task) error {
 	log := logger.GetLogger()
 	log.Info("Creating task", "title", task.Title, "status", task.Status)
-	return r.db.WithContext(ctx).Create(task).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return createTask(ctx, tx, task)
+	})
 }
 
 func (r *TaskRepositoryImpl) FindByID(ctx context.Context, id uint) (*domain.Task, error) {
 	var task domain.Task
-	err := r.db.WithContext(ctx).First(&task, id).Error
+	err := r.db.WithContext(ctx).Scopes(activeTasks).Preload("Labels", orderLabels).First(&task, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("task not found")
+			return nil, domain.NewNotFoundError("task not found")
 		}
 		return nil, err
 	}
+	if err := attachDerivedFields(r.db.WithContext(ctx), &task); err != nil {
+		return nil, err
+	}
 	return &task, nil
 }
 
-func (r *TaskRepositoryImpl) FindAll(ctx context.Context, page, limit int, status *domain.TaskStatus) ([]domain.Task, int64, error) {
+func (r *TaskRepositoryImpl) FindByKey(ctx context.Context, key string) (*domain.Task, error) {
+	var task domain.Task
+	err := r.db.WithContext(ctx).Scopes(activeTasks).Preload("Labels", orderLabels).Where("key = ?", key).First(&task).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFoundError("task not found")
+		}
+		return nil, err
+	}
+	if err := attachDerivedFields(r.db.WithContext(ctx), &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (r *TaskRepositoryImpl) FindAll(ctx context.Context, page, limit int, filter domain.TaskFilter) ([]domain.Task, int64, error) {
 	var tasks []domain.Task
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&domain.Task{})
-	if status != nil {
-		query = query.Where("status = ?", *status)
-	}
+	query := r.filterTasks(ctx, filter)
 
 	err := query.Count(&total).Error
 	if err != nil {
@@ -51,26 +82,861 @@ func (r *TaskRepositoryImpl) FindAll(ctx context.Context, page, limit int, statu
 	}
 
 	offset := (page - 1) * limit
-	err = query.Offset(offset).Limit(limit).Find(&tasks).Error
+	err = query.Preload("Labels", orderLabels).Order(taskOrder(taskSortOrDefault(filter.Sort), false)).Offset(offset).Limit(limit).Find(&tasks).Error
 	if err != nil {
 		return nil, 0, err
 	}
 
+	found := make([]*domain.Task, len(tasks))
+	for i := range tasks {
+		found[i] = &tasks[i]
+	}
+	if err := attachDerivedFields(r.db.WithContext(ctx), found...); err != nil {
+		return nil, 0, err
+	}
+
 	return tasks, total, nil
 }
 
+// FindPage lists tasks with keyset pagination: instead of skipping rows it
+// continues from the sort key of the cursor, so pages stay stable while tasks
+// are created and deep pages cost the same as the first one.
+func (r *TaskRepositoryImpl) FindPage(ctx context.Context, filter domain.TaskFilter, request domain.TaskPageRequest) (*domain.TaskPage, error) {
+	page := &domain.TaskPage{Limit: request.Limit}
+
+	if request.IncludeTotal {
+		var total int64
+		if err := r.filterTasks(ctx, filter).Count(&total).Error; err != nil {
+			return nil, err
+		}
+		page.Total = &total
+	}
+
+	sort := taskSortOrDefault(filter.Sort)
+	query := r.filterTasks(ctx, filter)
+	cursor := request.Cursor
+	backward := cursor != nil && cursor.Backward
+	if cursor != nil {
+		values, err := cursor.SortValues(sort)
+		if err != nil {
+			return nil, err
+		}
+		condition, args := keysetCondition(sort, values, cursor.ID, backward)
+		query = query.Where(condition, args...)
+	}
+	query = query.Order(taskOrder(sort, backward))
+
+	// One extra row tells whether there is another page in this direction.
+	var tasks []domain.Task
+	err := query.Preload("Labels", orderLabels).Limit(request.Limit + 1).Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	hasMore := len(tasks) > request.Limit
+	if hasMore {
+		tasks = tasks[:request.Limit]
+	}
+	if backward {
+		for i, j := 0, len(tasks)-1; i < j; i, j = i+1, j-1 {
+			tasks[i], tasks[j] = tasks[j], tasks[i]
+		}
+	}
+
+	if len(tasks) > 0 {
+		if hasMore || backward {
+			page.Next = domain.CursorAfter(&tasks[len(tasks)-1], sort)
+		}
+		if (hasMore && backward) || (cursor != nil && !backward) {
+			page.Prev = domain.CursorBefore(&tasks[0], sort)
+		}
+	}
+
+	found := make([]*domain.Task, len(tasks))
+	for i := range tasks {
+		found[i] = &tasks[i]
+	}
+	if err := attachDerivedFields(r.db.WithContext(ctx), found...); err != nil {
+		return nil, err
+	}
+
+	page.Tasks = tasks
+	return page, nil
+}
+
+// filterTasks returns a query over the tasks selected by filter.
+func (r *TaskRepositoryImpl) filterTasks(ctx context.Context, filter domain.TaskFilter) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&domain.Task{})
+	if filter.Deleted {
+		query = query.Where("deleted_at IS NOT NULL")
+	} else {
+		query = query.Scopes(activeTasks)
+	}
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.Priority != nil {
+		query = query.Where("priority = ?", *filter.Priority)
+	}
+	if filter.DueBefore != nil {
+		query = query.Where("due_at < ?", *filter.DueBefore)
+	}
+	if filter.DueAfter != nil {
+		query = query.Where("due_at > ?", *filter.DueAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filter.CreatedBefore)
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("created_at > ?", *filter.CreatedAfter)
+	}
+	if filter.UpdatedBefore != nil {
+		query = query.Where("updated_at < ?", *filter.UpdatedBefore)
+	}
+	if filter.UpdatedAfter != nil {
+		query = query.Where("updated_at > ?", *filter.UpdatedAfter)
+	}
+	if filter.TitleContains != "" {
+		query = query.Where("LOWER(title) LIKE ? ESCAPE '!'", "%"+escapeLike(strings.ToLower(filter.TitleContains))+"%")
+	}
+	if filter.Overdue != nil {
+		now := time.Now()
+		if *filter.Overdue {
+			query = query.Where("due_at < ? AND status <> ?", now, domain.StatusDone)
+		} else {
+			query = query.Where("(due_at IS NULL OR due_at >= ? OR status = ?)", now, domain.StatusDone)
+		}
+	}
+
+	if filter.AssigneeID != nil {
+		query = query.Where("assignee_id = ?", *filter.AssigneeID)
+	}
+	if filter.Unassigned {
+		query = query.Where("assignee_id IS NULL")
+	}
+	if filter.CreatedBy != nil {
+		query = query.Where("created_by = ?", *filter.CreatedBy)
+	}
+	if filter.ProjectID != nil {
+		query = query.Where("project_id = ?", *filter.ProjectID)
+	}
+	if filter.ParentID != nil {
+		query = query.Where("parent_id = ?", *filter.ParentID)
+	}
+	if filter.TopLevel {
+		query = query.Where("parent_id IS NULL")
+	}
+	if len(filter.Labels) > 0 {
+		labelled := r.db.WithContext(ctx).Table("task_labels").
+			Select("task_labels.task_id").
+			Joins("JOIN labels ON labels.id = task_labels.label_id").
+			Where("labels.name IN ?", filter.Labels)
+		if filter.LabelMode == domain.LabelMatchAll {
+			labelled = labelled.Group("task_labels.task_id").Having("COUNT(DISTINCT labels.name) = ?", len(filter.Labels))
+		}
+		query = query.Where("id IN (?)", labelled)
+	}
+	return query
+}
+
 func (r *TaskRepositoryImpl) Update(ctx context.Context, task *domain.Task) error {
 	log := logger.GetLogger()
 	log.Info("Updating task", "id", task.ID, "title", task.Title, "status", task.Status)
-	return r.db.WithContext(ctx).Save(task).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return updateTask(ctx, tx, task)
+	})
 }
 
-func (r *TaskRepositoryImpl) Delete(ctx context.Context, id uint) error {
+func (r *TaskRepositoryImpl) Delete(ctx context.Context, id uint, version *uint) error {
 	log := logger.GetLogger()
-	log.Info("Deleting task", "id", id)
-	result := r.db.WithContext(ctx).Delete(&domain.Task{}, id)
-	if result.RowsAffected == 0 {
-		return errors.New("task not found")
+	log.Info("Deleting task", "id", id, "version", version)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return deleteTask(ctx, tx, id, version)
+	})
+}
+
+func (r *TaskRepositoryImpl) Restore(ctx context.Context, id uint) (*domain.Task, error) {
+	log := logger.GetLogger()
+	log.Info("Restoring task", "id", id)
+
+	var task *domain.Task
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		trashed, err := lockTaskRow(tx.Where("deleted_at IS NOT NULL"), id)
+		if err != nil {
+			return err
+		}
+		err = tx.Model(trashed).
+			Updates(map[string]any{"deleted_at": nil, "version": gorm.Expr("version + 1")}).Error
+		if err != nil {
+			return err
+		}
+		if err := recordTaskEvent(ctx, tx, id, domain.TaskEventRestored, domain.FieldChanges{}); err != nil {
+			return err
+		}
+		task, err = reloadTask(tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (r *TaskRepositoryImpl) Purge(ctx context.Context, id uint, version *uint) error {
+	log := logger.GetLogger()
+	log.Info("Purging task", "id", id, "version", version)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		before, err := lockTaskRow(tx, id)
+		if err != nil {
+			return err
+		}
+		if version != nil && before.Version != *version {
+			return domain.ErrVersionMismatch
+		}
+		return purgeTask(ctx, tx, before)
+	})
+}
+
+func (r *TaskRepositoryImpl) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	log := logger.GetLogger()
+	log.Info("Purging trashed tasks", "deleted_before", cutoff)
+
+	var purged int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var expired []domain.Task
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+			Find(&expired).Error
+		if err != nil {
+			return err
+		}
+		for i := range expired {
+			if err := purgeTask(ctx, tx, &expired[i]); err != nil {
+				return err
+			}
+		}
+		purged = int64(len(expired))
+		return nil
+	})
+	return purged, err
+}
+
+func (r *TaskRepositoryImpl) AddDependency(ctx context.Context, blockedID, blockerID uint) error {
+	log := logger.GetLogger()
+	log.Info("Adding task dependency", "id", blockedID, "blocker_id", blockerID)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Serialise dependency inserts so that two concurrent edges cannot
+		// close a cycle that neither of them sees on its own.
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", dependencyLockID).Error; err != nil {
+			return err
+		}
+		if _, err := lockTask(tx, blockedID); err != nil {
+			return err
+		}
+		if _, err := lockTask(tx, blockerID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.NewUnprocessableError("blocking task not found")
+			}
+			return err
+		}
+
+		// The new edge closes a cycle if the blocker is already reachable
+		// from the blocked task by following "blocks" edges.
+		var cycle bool
+		err := tx.Raw(`
+			WITH RECURSIVE downstream AS (
+				SELECT blocked_id FROM task_dependencies WHERE blocker_id = ?
+				UNION
+				SELECT d.blocked_id
+				FROM task_dependencies d JOIN downstream s ON d.blocker_id = s.blocked_id
+			)
+			SELECT EXISTS (SELECT 1 FROM downstream WHERE blocked_id = ?)`,
+			blockedID, blockerID,
+		).Scan(&cycle).Error
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return domain.NewConflictError("dependency would create a cycle")
+		}
+
+		before, err := blockerIDs(tx, blockedID)
+		if err != nil {
+			return err
+		}
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&domain.TaskDependency{
+			BlockerID: blockerID,
+			BlockedID: blockedID,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		return recordDependencyChange(ctx, tx, blockedID, before)
+	})
+}
+
+func (r *TaskRepositoryImpl) RemoveDependency(ctx context.Context, blockedID, blockerID uint) error {
+	log := logger.GetLogger()
+	log.Info("Removing task dependency", "id", blockedID, "blocker_id", blockerID)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockTask(tx, blockedID); err != nil {
+			return err
+		}
+
+		before, err := blockerIDs(tx, blockedID)
+		if err != nil {
+			return err
+		}
+		result := tx.Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).Delete(&domain.TaskDependency{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.NewNotFoundError("dependency not found")
+		}
+		return recordDependencyChange(ctx, tx, blockedID, before)
+	})
+}
+
+func (r *TaskRepositoryImpl) FindDependencies(ctx context.Context, id uint) (*domain.TaskDependencies, error) {
+	db := r.db.WithContext(ctx)
+	dependencies := &domain.TaskDependencies{}
+
+	err := db.Scopes(activeTasks).Preload("Labels", orderLabels).
+		Where("id IN (SELECT blocker_id FROM task_dependencies WHERE blocked_id = ?)", id).
+		Order("priority_rank DESC, created_at DESC, id DESC").
+		Find(&dependencies.BlockedBy).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Scopes(activeTasks).Preload("Labels", orderLabels).
+		Where("id IN (SELECT blocked_id FROM task_dependencies WHERE blocker_id = ?)", id).
+		Order("priority_rank DESC, created_at DESC, id DESC").
+		Find(&dependencies.Blocks).Error
+	if err != nil {
+		return nil, err
 	}
-	return result.Error
+
+	related := make([]*domain.Task, 0, len(dependencies.BlockedBy)+len(dependencies.Blocks))
+	for i := range dependencies.BlockedBy {
+		related = append(related, &dependencies.BlockedBy[i])
+	}
+	for i := range dependencies.Blocks {
+		related = append(related, &dependencies.Blocks[i])
+	}
+	if err := attachDerivedFields(db, related...); err != nil {
+		return nil, err
+	}
+
+	return dependencies, nil
+}
+
+func (r *TaskRepositoryImpl) AddLabel(ctx context.Context, taskID, labelID uint) (*domain.Task, error) {
+	log := logger.GetLogger()
+	log.Info("Adding label to task", "id", taskID, "label_id", labelID)
+	return r.changeLabels(ctx, taskID, labelID, true)
+}
+
+func (r *TaskRepositoryImpl) RemoveLabel(ctx context.Context, taskID, labelID uint) (*domain.Task, error) {
+	log := logger.GetLogger()
+	log.Info("Removing label from task", "id", taskID, "label_id", labelID)
+	return r.changeLabels(ctx, taskID, labelID, false)
+}
+
+// changeLabels attaches or detaches a label and records the label sets
+// before and after as an UPDATED event. Attaching a label twice or
+// detaching one that is not attached is a no-op without an event.
+func (r *TaskRepositoryImpl) changeLabels(ctx context.Context, taskID, labelID uint, attach bool) (*domain.Task, error) {
+	var task *domain.Task
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockTask(tx, taskID); err != nil {
+			return err
+		}
+
+		var label domain.Label
+		if err := tx.First(&label, labelID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domain.NewNotFoundError("label not found")
+			}
+			return err
+		}
+
+		before, err := reloadTask(tx, taskID)
+		if err != nil {
+			return err
+		}
+
+		link := taskLabel{TaskID: taskID, LabelID: labelID}
+		var result *gorm.DB
+		if attach {
+			result = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&link)
+		} else {
+			result = tx.Where("task_id = ? AND label_id = ?", taskID, labelID).Delete(&taskLabel{})
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			if err := bumpTaskVersion(tx, taskID); err != nil {
+				return err
+			}
+		}
+
+		task, err = reloadTask(tx, taskID)
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		return recordTaskEvent(ctx, tx, taskID, domain.TaskEventUpdated, domain.FieldChanges{{
+			Field:  "labels",
+			Before: labelNames(before.Labels),
+			After:  labelNames(task.Labels),
+		}})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// ApplyBatch runs every change in a savepoint of one transaction, so that a
+// failed change can be undone without losing the others.
+func (r *TaskRepositoryImpl) ApplyBatch(ctx context.Context, changes []domain.TaskChange, atomic bool) ([]error, error) {
+	log := logger.GetLogger()
+	log.Info("Applying task batch", "changes", len(changes), "atomic", atomic)
+
+	errs := make([]error, len(changes))
+	aborted := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Take the hierarchy lock before any row lock, in the same order as
+		// a single write does, so that batches cannot deadlock each other.
+		for _, change := range changes {
+			moves, err := changesHierarchy(tx, change)
+			if err != nil {
+				return err
+			}
+			if moves {
+				if err := lockHierarchy(tx); err != nil {
+					return err
+				}
+				break
+			}
+		}
+		for i, change := range changes {
+			errs[i] = tx.Transaction(func(tx *gorm.DB) error {
+				switch change.Type {
+				case domain.BulkOperationCreate:
+					return createTask(ctx, tx, change.Task)
+				case domain.BulkOperationUpdate:
+					return updateTask(ctx, tx, change.Task)
+				case domain.BulkOperationDelete:
+					return deleteTask(ctx, tx, change.ID, change.Version)
+				default:
+					return domain.NewValidationError("invalid operation")
+				}
+			})
+			if errs[i] != nil && atomic {
+				aborted = true
+				return errs[i]
+			}
+		}
+		return nil
+	})
+	if err != nil && !aborted {
+		return nil, err
+	}
+	return errs, nil
+}
+
+type taskLabel struct {
+	TaskID  uint `gorm:"primaryKey"`
+	LabelID uint `gorm:"primaryKey"`
+}
+
+func (taskLabel) TableName() string {
+	return "task_labels"
+}
+
+func orderLabels(db *gorm.DB) *gorm.DB {
+	return db.Order("labels.name")
+}
+
+func reloadTask(tx *gorm.DB, id uint) (*domain.Task, error) {
+	var task domain.Task
+	if err := tx.Preload("Labels", orderLabels).First(&task, id).Error; err != nil {
+		return nil, err
+	}
+	if err := attachDerivedFields(tx, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// attachDerivedFields fills the fields of tasks that are computed from
+// other rows rather than stored: subtask progress, the blocked flag and the
+// number of comments.
+func attachDerivedFields(db *gorm.DB, tasks ...*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+
+	if err := attachProgress(db, ids, tasks); err != nil {
+		return err
+	}
+	if err := attachBlocked(db, ids, tasks); err != nil {
+		return err
+	}
+	return attachCommentCounts(db, ids, tasks)
+}
+
+// attachProgress counts the active children of each task, and how many of
+// them are DONE, in a single grouped query.
+func attachProgress(db *gorm.DB, ids []uint, tasks []*domain.Task) error {
+	var rows []struct {
+		ParentID uint
+		Total    int64
+		Done     int64
+	}
+	err := db.Model(&domain.Task{}).Scopes(activeTasks).
+		Select("parent_id, COUNT(*) AS total, COUNT(*) FILTER (WHERE status = ?) AS done", domain.StatusDone).
+		Where("parent_id IN ?", ids).
+		Group("parent_id").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	progress := make(map[uint]domain.TaskProgress, len(rows))
+	for _, row := range rows {
+		progress[row.ParentID] = domain.TaskProgress{Done: row.Done, Total: row.Total}
+	}
+	for _, task := range tasks {
+		task.Progress = progress[task.ID]
+	}
+	return nil
+}
+
+// attachBlocked flags tasks that have at least one active blocker which is
+// not DONE yet.
+func attachBlocked(db *gorm.DB, ids []uint, tasks []*domain.Task) error {
+	var blocked []uint
+	err := db.Table("task_dependencies").
+		Select("DISTINCT task_dependencies.blocked_id").
+		Joins("JOIN tasks blockers ON blockers.id = task_dependencies.blocker_id").
+		Where("task_dependencies.blocked_id IN ?", ids).
+		Where("blockers.status <> ? AND blockers.deleted_at IS NULL", domain.StatusDone).
+		Scan(&blocked).Error
+	if err != nil {
+		return err
+	}
+
+	isBlocked := make(map[uint]bool, len(blocked))
+	for _, id := range blocked {
+		isBlocked[id] = true
+	}
+	for _, task := range tasks {
+		task.IsBlocked = isBlocked[task.ID]
+	}
+	return nil
+}
+
+func attachCommentCounts(db *gorm.DB, ids []uint, tasks []*domain.Task) error {
+	var rows []struct {
+		TaskID uint
+		Count  int64
+	}
+	err := db.Model(&domain.Comment{}).
+		Select("task_id, COUNT(*) AS count").
+		Where("task_id IN ?", ids).
+		Group("task_id").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.TaskID] = row.Count
+	}
+	for _, task := range tasks {
+		task.CommentCount = counts[task.ID]
+	}
+	return nil
+}
+
+func blockerIDs(tx *gorm.DB, blockedID uint) ([]uint, error) {
+	var ids []uint
+	err := tx.Model(&domain.TaskDependency{}).Where("blocked_id = ?", blockedID).Order("blocker_id").Pluck("blocker_id", &ids).Error
+	return ids, err
+}
+
+func recordDependencyChange(ctx context.Context, tx *gorm.DB, blockedID uint, before []uint) error {
+	if err := bumpTaskVersion(tx, blockedID); err != nil {
+		return err
+	}
+	after, err := blockerIDs(tx, blockedID)
+	if err != nil {
+		return err
+	}
+	return recordTaskEvent(ctx, tx, blockedID, domain.TaskEventUpdated, domain.FieldChanges{{
+		Field:  "blocked_by",
+		Before: before,
+		After:  after,
+	}})
+}
+
+func labelNames(labels []domain.Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.Name)
+	}
+	return names
+}
+
+func createTask(ctx context.Context, tx *gorm.DB, task *domain.Task) error {
+	if task.ParentID != nil {
+		if err := lockHierarchy(tx); err != nil {
+			return err
+		}
+		if err := checkParent(tx, task.ID, *task.ParentID); err != nil {
+			return err
+		}
+	}
+	if task.ProjectID != nil {
+		key, err := nextTaskKey(tx, *task.ProjectID)
+		if err != nil {
+			return err
+		}
+		task.Key = &key
+	}
+	task.Version = 1
+	if err := tx.Omit(clause.Associations).Create(task).Error; err != nil {
+		return err
+	}
+	return recordTaskEvent(ctx, tx, task.ID, domain.TaskEventCreated, domain.DiffTasks(nil, task))
+}
+
+func updateTask(ctx context.Context, tx *gorm.DB, task *domain.Task) error {
+	moves, err := movesTask(tx, task)
+	if err != nil {
+		return err
+	}
+	if moves {
+		if err := lockHierarchy(tx); err != nil {
+			return err
+		}
+	}
+	before, err := lockTask(tx, task.ID)
+	if err != nil {
+		return err
+	}
+	// task was read before the lock was taken; a newer version means
+	// someone else wrote in between and saving would overwrite it.
+	if before.Version != task.Version {
+		return domain.ErrVersionMismatch
+	}
+	if parentChanged(before, task) {
+		// Already held, unless an earlier change of a batch moved the task
+		// after the batch decided whether to take it.
+		if err := lockHierarchy(tx); err != nil {
+			return err
+		}
+		if err := checkParent(tx, task.ID, *task.ParentID); err != nil {
+			return err
+		}
+	}
+	task.Version++
+	if err := tx.Omit(clause.Associations).Save(task).Error; err != nil {
+		return err
+	}
+	changes := domain.DiffTasks(before, task)
+	if len(changes) == 0 {
+		return nil
+	}
+	return recordTaskEvent(ctx, tx, task.ID, domain.TaskEventUpdated, changes)
+}
+
+func deleteTask(ctx context.Context, tx *gorm.DB, id uint, version *uint) error {
+	// The row lock also waits for a concurrent move under the task, which
+	// holds the task FOR SHARE in checkParent until it commits.
+	before, err := lockTask(tx, id)
+	if err != nil {
+		return err
+	}
+	if version != nil && before.Version != *version {
+		return domain.ErrVersionMismatch
+	}
+	err = tx.Model(&domain.Task{}).Where("id = ?", id).
+		UpdateColumns(map[string]any{"deleted_at": time.Now(), "version": gorm.Expr("version + 1")}).Error
+	if err != nil {
+		return err
+	}
+	return recordTaskEvent(ctx, tx, id, domain.TaskEventDeleted, domain.DiffTasks(before, nil))
+}
+
+func purgeTask(ctx context.Context, tx *gorm.DB, task *domain.Task) error {
+	if err := tx.Delete(&domain.Task{}, task.ID).Error; err != nil {
+		return err
+	}
+	return recordTaskEvent(ctx, tx, task.ID, domain.TaskEventPurged, domain.DiffTasks(task, nil))
+}
+
+// lockHierarchy serialises writes to parent links, so that two concurrent
+// moves cannot close a cycle that neither of them sees on its own. It must
+// be taken before any row lock.
+func lockHierarchy(tx *gorm.DB) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(?)", hierarchyLockID).Error
+}
+
+// changesHierarchy reports whether a change of a batch may give a task a
+// new parent.
+func changesHierarchy(tx *gorm.DB, change domain.TaskChange) (bool, error) {
+	switch change.Type {
+	case domain.BulkOperationCreate:
+		return change.Task.ParentID != nil, nil
+	case domain.BulkOperationUpdate:
+		return movesTask(tx, change.Task)
+	default:
+		return false, nil
+	}
+}
+
+// movesTask reports whether saving task gives it a new parent, going by
+// the parent it has now. It reads without a lock, so that the hierarchy
+// lock can be taken before the row lock; the version check under the row
+// lock catches a move in between.
+func movesTask(tx *gorm.DB, task *domain.Task) (bool, error) {
+	if task.ParentID == nil {
+		return false, nil
+	}
+	var current domain.Task
+	err := tx.Select("id", "parent_id").First(&current, task.ID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// lockTask reports the missing task.
+			return false, nil
+		}
+		return false, err
+	}
+	return parentChanged(&current, task), nil
+}
+
+// parentChanged reports whether task has a parent other than before's.
+// Detaching a task is not a change here: it cannot close a cycle.
+func parentChanged(before, task *domain.Task) bool {
+	return task.ParentID != nil && (before.ParentID == nil || *before.ParentID != *task.ParentID)
+}
+
+// checkParent fails unless parentID is an active task and making it the
+// parent of id keeps the hierarchy free of cycles. It reads the tree as
+// the transaction sees it, including its own earlier writes, and must run
+// under lockHierarchy. The parent stays locked FOR SHARE, so it cannot be
+// moved to the trash before the new link commits.
+func checkParent(tx *gorm.DB, id, parentID uint) error {
+	if parentID == id {
+		return domain.NewConflictError("task hierarchy cannot contain cycles")
+	}
+	var parent domain.Task
+	err := tx.Scopes(activeTasks).Clauses(clause.Locking{Strength: "SHARE"}).Select("id").First(&parent, parentID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.NewUnprocessableError("parent task not found")
+		}
+		return err
+	}
+
+	// The depth bound keeps the walk finite even if a cycle slipped in.
+	var cycle bool
+	err = tx.Raw(`
+		WITH RECURSIVE ancestors AS (
+			SELECT parent_id, 1 AS depth FROM tasks WHERE id = ?
+			UNION ALL
+			SELECT t.parent_id, a.depth + 1
+			FROM tasks t JOIN ancestors a ON t.id = a.parent_id
+			WHERE a.depth < ?
+		)
+		SELECT EXISTS (SELECT 1 FROM ancestors WHERE parent_id = ?)`,
+		parentID, maxHierarchyDepth, id,
+	).Scan(&cycle).Error
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return domain.NewConflictError("task hierarchy cannot contain cycles")
+	}
+	return nil
+}
+
+// nextTaskKey bumps the task counter of a project and returns the key of
+// the new task. The row lock on the project serialises concurrent creates,
+// so numbers are sequential without gaps from racing transactions.
+func nextTaskKey(tx *gorm.DB, projectID uint) (string, error) {
+	var project domain.Project
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&project, projectID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", domain.NewUnprocessableError("project not found")
+		}
+		return "", err
+	}
+	if project.Archived {
+		return "", domain.NewConflictError("project is archived")
+	}
+
+	project.TaskCounter++
+	if err := tx.Model(&project).Update("task_counter", project.TaskCounter).Error; err != nil {
+		return "", err
+	}
+	return project.TaskKey(project.TaskCounter), nil
+}
+
+// activeTasks limits a query to tasks that are not in the trash. Soft
+// deletion is scoped here rather than by the ORM, which keeps the domain
+// model free of persistence types.
+func activeTasks(db *gorm.DB) *gorm.DB {
+	return db.Where("tasks.deleted_at IS NULL")
+}
+
+// lockTask locks an active task for update.
+func lockTask(tx *gorm.DB, id uint) (*domain.Task, error) {
+	return lockTaskRow(tx.Scopes(activeTasks), id)
+}
+
+// lockTaskRow locks a task whether or not it is in the trash.
+func lockTaskRow(tx *gorm.DB, id uint) (*domain.Task, error) {
+	var task domain.Task
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&task, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFoundError("task not found")
+		}
+		return nil, err
+	}
+	return &task, nil
+}
+
+// bumpTaskVersion records a write to a task that does not go through Save,
+// such as a label or dependency change.
+func bumpTaskVersion(tx *gorm.DB, id uint) error {
+	return tx.Model(&domain.Task{}).Where("id = ?", id).
+		UpdateColumn("version", gorm.Expr("version + 1")).Error
+}
+
+func recordTaskEvent(ctx context.Context, tx *gorm.DB, taskID uint, eventType domain.TaskEventType, changes domain.FieldChanges) error {
+	return tx.Create(&domain.TaskEvent{
+		TaskID:  taskID,
+		Type:    eventType,
+		Actor:   domain.ActorFromContext(ctx),
+		Changes: changes,
+	}).Error
 }
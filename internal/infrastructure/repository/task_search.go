@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	"task-be/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+// searchConfig is the Postgres text search configuration used by the
+// search_vector column (see migration 000016).
+const searchConfig = "english"
+
+// fallbackSnippetLength is the number of characters of context kept around a
+// match when highlights are built without ts_headline.
+const fallbackSnippetLength = 160
+
+// headlineStart and headlineStop mark matches in ts_headline output. They
+// are control characters rather than tags, so that the text can be escaped
+// before the marks are turned into HTML.
+const (
+	headlineStart = "\x02"
+	headlineStop  = "\x03"
+)
+
+var headlineOptions = fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxWords=35, MinWords=15, MaxFragments=2`,
+	headlineStart, headlineStop)
+
+var headlineMarks = strings.NewReplacer(headlineStart, domain.HighlightStart, headlineStop, domain.HighlightStop)
+
+type searchHit struct {
+	ID                   uint
+	Rank                 float64
+	TitleHighlight       string
+	DescriptionHighlight string
+}
+
+// Search uses the full-text index on Postgres and falls back to a
+// case-insensitive substring match on other databases.
+func (r *TaskRepositoryImpl) Search(ctx context.Context, text string, page, limit int, filter domain.TaskFilter) ([]domain.TaskSearchResult, int64, error) {
+	if r.db.Dialector.Name() != "postgres" {
+		return r.searchILike(ctx, text, page, limit, filter)
+	}
+
+	tsquery := fmt.Sprintf("websearch_to_tsquery('%s', ?)", searchConfig)
+	query := r.filterTasks(ctx, filter).Where("search_vector @@ "+tsquery, text)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var hits []searchHit
+	offset := (page - 1) * limit
+	err := query.
+		Select(fmt.Sprintf(`id,
+			ts_rank(search_vector, %[1]s) AS rank,
+			ts_headline('%[2]s', title, %[1]s, ?) AS title_highlight,
+			ts_headline('%[2]s', coalesce(description, ''), %[1]s, ?) AS description_highlight`, tsquery, searchConfig),
+			text, text, headlineOptions, text, headlineOptions).
+		Order("rank DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Scan(&hits).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range hits {
+		hits[i].TitleHighlight = renderHeadline(hits[i].TitleHighlight)
+		hits[i].DescriptionHighlight = renderHeadline(hits[i].DescriptionHighlight)
+	}
+
+	results, err := r.loadSearchResults(ctx, hits)
+	if err != nil {
+		return nil, 0, err
+	}
+	return results, total, nil
+}
+
+// renderHeadline turns ts_headline output into HTML: the text is escaped
+// and the match marks become domain.HighlightStart and HighlightStop.
+func renderHeadline(headline string) string {
+	return headlineMarks.Replace(html.EscapeString(headline))
+}
+
+// searchILike matches the query as a case-insensitive substring of the title
+// or description, ranking title matches first. It needs no index and serves
+// databases without Postgres full-text search.
+func (r *TaskRepositoryImpl) searchILike(ctx context.Context, text string, page, limit int, filter domain.TaskFilter) ([]domain.TaskSearchResult, int64, error) {
+	pattern := "%" + escapeLike(strings.ToLower(text)) + "%"
+	query := r.filterTasks(ctx, filter).
+		Where(`(LOWER(title) LIKE ? ESCAPE '!' OR LOWER(description) LIKE ? ESCAPE '!')`, pattern, pattern)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var tasks []domain.Task
+	offset := (page - 1) * limit
+	err := query.
+		Order(gorm.Expr(`CASE WHEN LOWER(title) LIKE ? ESCAPE '!' THEN 0 ELSE 1 END`, pattern)).
+		Order("priority_rank DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Find(&tasks).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]searchHit, len(tasks))
+	for i, task := range tasks {
+		hits[i] = searchHit{
+			ID:                   task.ID,
+			Rank:                 0.5,
+			TitleHighlight:       highlightSubstring(task.Title, text),
+			DescriptionHighlight: highlightSubstring(task.Description, text),
+		}
+		if strings.Contains(strings.ToLower(task.Title), strings.ToLower(text)) {
+			hits[i].Rank = 1
+		}
+	}
+
+	results, err := r.loadSearchResults(ctx, hits)
+	if err != nil {
+		return nil, 0, err
+	}
+	return results, total, nil
+}
+
+// loadSearchResults loads the tasks behind hits, keeping the order of hits.
+// The hits were already filtered, trashed tasks included only on request.
+func (r *TaskRepositoryImpl) loadSearchResults(ctx context.Context, hits []searchHit) ([]domain.TaskSearchResult, error) {
+	if len(hits) == 0 {
+		return []domain.TaskSearchResult{}, nil
+	}
+
+	ids := make([]uint, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+
+	var tasks []domain.Task
+	if err := r.db.WithContext(ctx).Preload("Labels", orderLabels).Where("id IN ?", ids).Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]*domain.Task, len(tasks))
+	for i := range tasks {
+		byID[tasks[i].ID] = &tasks[i]
+	}
+
+	results := make([]domain.TaskSearchResult, 0, len(hits))
+	for _, hit := range hits {
+		task, ok := byID[hit.ID]
+		if !ok {
+			continue
+		}
+		results = append(results, domain.TaskSearchResult{
+			Task:                 *task,
+			Rank:                 hit.Rank,
+			TitleHighlight:       hit.TitleHighlight,
+			DescriptionHighlight: hit.DescriptionHighlight,
+		})
+	}
+
+	found := make([]*domain.Task, len(results))
+	for i := range results {
+		found[i] = &results[i].Task
+	}
+	if err := attachDerivedFields(r.db.WithContext(ctx), found...); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// escapeLike escapes LIKE wildcards with '!', which unlike a backslash means
+// the same in string literals of every SQL dialect.
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
+
+// highlightSubstring marks every case-insensitive occurrence of term in text
+// and trims long text to the context around the first match, approximating
+// ts_headline. The result is HTML like renderHeadline's.
+func highlightSubstring(text, term string) string {
+	lowerText, lowerTerm := strings.ToLower(text), strings.ToLower(term)
+	// Lowercasing can change byte lengths outside ASCII; skip highlighting
+	// rather than cut runes apart.
+	if term == "" || len(lowerText) != len(text) || len(lowerTerm) != len(term) {
+		return html.EscapeString(text)
+	}
+
+	first := max(0, strings.Index(lowerText, lowerTerm))
+	start, end := 0, len(text)
+	if utf8.RuneCountInString(text) > fallbackSnippetLength {
+		start = max(0, first-fallbackSnippetLength/2)
+		end = min(len(text), first+len(term)+fallbackSnippetLength/2)
+		for start > 0 && !utf8.RuneStart(text[start]) {
+			start--
+		}
+		for end < len(text) && !utf8.RuneStart(text[end]) {
+			end++
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("...")
+	}
+	for i := start; i < end; {
+		match := strings.Index(lowerText[i:end], lowerTerm)
+		if match < 0 {
+			b.WriteString(html.EscapeString(text[i:end]))
+			break
+		}
+		b.WriteString(html.EscapeString(text[i : i+match]))
+		b.WriteString(domain.HighlightStart + html.EscapeString(text[i+match:i+match+len(term)]) + domain.HighlightStop)
+		i += match + len(term)
+	}
+	if end < len(text) {
+		b.WriteString("...")
+	}
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHighlightSubstring(t *testing.T) {
+	tests := []struct {
+		text     string
+		term     string
+		expected string
+	}{
+		{"Fix login redirect", "LOGIN", "Fix <mark>login</mark> redirect"},
+		{"Login, then login again", "login", "<mark>Login</mark>, then <mark>login</mark> again"},
+		{"No match here", "login", "No match here"},
+		{"<script>alert(1)</script> login", "login", "&lt;script&gt;alert(1)&lt;/script&gt; <mark>login</mark>"},
+		{"Fix <b> tag", "<b>", "Fix <mark>&lt;b&gt;</mark> tag"},
+	}
+	for _, tt := range tests {
+		if got := highlightSubstring(tt.text, tt.term); got != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, got)
+		}
+	}
+}
+
+func TestHighlightSubstringTrimsLongText(t *testing.T) {
+	text := strings.Repeat("lorem ipsum ", 50) + "needle" + strings.Repeat(" dolor sit", 50)
+
+	got := highlightSubstring(text, "needle")
+	if !strings.Contains(got, "<mark>needle</mark>") {
+		t.Errorf("Expected highlighted match, got %q", got)
+	}
+	if !strings.HasPrefix(got, "...") || !strings.HasSuffix(got, "...") {
+		t.Errorf("Expected trimmed snippet, got %q", got)
+	}
+	if len(got) > fallbackSnippetLength+len("<mark></mark>......")+len("needle") {
+		t.Errorf("Expected snippet of about %d characters, got %d", fallbackSnippetLength, len(got))
+	}
+}
+
+func TestRenderHeadlineEscapesText(t *testing.T) {
+	got := renderHeadline("<script>alert(1)</script> \x02login\x03 & \x02logout\x03")
+	expected := "&lt;script&gt;alert(1)&lt;/script&gt; <mark>login</mark> &amp; <mark>logout</mark>"
+	if got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	if got := escapeLike("100%_done!"); got != "100!%!_done!!" {
+		t.Errorf("Expected 100!%%!_done!!, got %s", got)
+	}
+}
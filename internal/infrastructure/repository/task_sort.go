@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"strings"
+
+	"task-be/internal/domain"
+)
+
+// taskSortColumns maps the whitelisted sort fields to their columns. Only
+// these names are ever interpolated into ORDER BY and keyset conditions.
+var taskSortColumns = map[domain.TaskSortField]string{
+	domain.SortByPriority:  "priority_rank",
+	domain.SortByCreatedAt: "created_at",
+	domain.SortByUpdatedAt: "updated_at",
+	domain.SortByDueAt:     "due_at",
+	domain.SortByTitle:     "title",
+}
+
+// nullableSortFields sort NULL after every value, i.e. last ascending and
+// first descending.
+var nullableSortFields = map[domain.TaskSortField]bool{
+	domain.SortByDueAt: true,
+}
+
+func taskSortOrDefault(sort []domain.TaskSort) []domain.TaskSort {
+	if len(sort) == 0 {
+		return domain.DefaultTaskSort()
+	}
+	return sort
+}
+
+// taskOrder returns the ORDER BY clause for sort, tie-broken by ID, or the
+// reverse order when reverse is set.
+func taskOrder(sort []domain.TaskSort, reverse bool) string {
+	parts := make([]string, 0, len(sort)+1)
+	for _, key := range sort {
+		descending := key.Descending != reverse
+		part := taskSortColumns[key.Field] + " ASC"
+		if descending {
+			part = taskSortColumns[key.Field] + " DESC"
+		}
+		if nullableSortFields[key.Field] {
+			if descending {
+				part += " NULLS FIRST"
+			} else {
+				part += " NULLS LAST"
+			}
+		}
+		parts = append(parts, part)
+	}
+	if lastKeyDescending(sort) != reverse {
+		parts = append(parts, "id DESC")
+	} else {
+		parts = append(parts, "id ASC")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// keysetCondition returns the condition selecting the tasks listed after the
+// position given by the sort key values and ID, or before it when backward
+// is set. Mixed directions rule out a row comparison, so it expands to
+// (k1 beyond v1) OR (k1 = v1 AND k2 beyond v2) OR ... with the ID last.
+func keysetCondition(sort []domain.TaskSort, values []any, id uint, backward bool) (string, []any) {
+	var disjuncts []string
+	var args []any
+	var equal []string
+	var equalArgs []any
+
+	for i, key := range sort {
+		column := taskSortColumns[key.Field]
+		beyond, beyondArgs := beyondCondition(column, values[i], key.Descending != backward, nullableSortFields[key.Field])
+		if beyond != "" {
+			disjuncts = append(disjuncts, "("+strings.Join(append(append([]string{}, equal...), beyond), " AND ")+")")
+			args = append(append(args, equalArgs...), beyondArgs...)
+		}
+
+		if values[i] == nil {
+			equal = append(equal, column+" IS NULL")
+		} else {
+			equal = append(equal, column+" = ?")
+			equalArgs = append(equalArgs, values[i])
+		}
+	}
+
+	beyond, beyondArgs := beyondCondition("id", id, lastKeyDescending(sort) != backward, false)
+	disjuncts = append(disjuncts, "("+strings.Join(append(equal, beyond), " AND ")+")")
+	args = append(append(args, equalArgs...), beyondArgs...)
+
+	return "(" + strings.Join(disjuncts, " OR ") + ")", args
+}
+
+// beyondCondition compares column with value in listing direction. A nil
+// value is NULL, which sorts after every value; an empty condition means no
+// row lies beyond it.
+func beyondCondition(column string, value any, descending, nullable bool) (string, []any) {
+	if descending {
+		if value == nil {
+			return column + " IS NOT NULL", nil
+		}
+		return column + " < ?", []any{value}
+	}
+	if value == nil {
+		return "", nil
+	}
+	if nullable {
+		return "(" + column + " > ? OR " + column + " IS NULL)", []any{value}
+	}
+	return column + " > ?", []any{value}
+}
+
+func lastKeyDescending(sort []domain.TaskSort) bool {
+	return len(sort) > 0 && sort[len(sort)-1].Descending
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"task-be/internal/domain"
+)
+
+func TestTaskOrder(t *testing.T) {
+	sort := []domain.TaskSort{{Field: domain.SortByPriority, Descending: true}, {Field: domain.SortByDueAt}}
+
+	if got := taskOrder(sort, false); got != "priority_rank DESC, due_at ASC NULLS LAST, id ASC" {
+		t.Errorf("Unexpected order %q", got)
+	}
+	if got := taskOrder(sort, true); got != "priority_rank ASC, due_at DESC NULLS FIRST, id DESC" {
+		t.Errorf("Unexpected reversed order %q", got)
+	}
+	if got := taskOrder(domain.DefaultTaskSort(), false); got != "priority_rank DESC, created_at DESC, id DESC" {
+		t.Errorf("Unexpected default order %q", got)
+	}
+}
+
+func TestKeysetCondition(t *testing.T) {
+	due := time.Date(2023, 11, 1, 17, 0, 0, 0, time.UTC)
+	sort := []domain.TaskSort{{Field: domain.SortByPriority, Descending: true}, {Field: domain.SortByDueAt}}
+
+	tests := []struct {
+		name     string
+		values   []any
+		backward bool
+		sql      string
+		args     []any
+	}{
+		{
+			name:   "forward",
+			values: []any{3, due},
+			sql: "((priority_rank < ?) OR (priority_rank = ? AND (due_at > ? OR due_at IS NULL)) OR " +
+				"(priority_rank = ? AND due_at = ? AND id > ?))",
+			args: []any{3, 3, due, 3, due, uint(9)},
+		},
+		{
+			name:   "forward from missing due date",
+			values: []any{3, nil},
+			sql:    "((priority_rank < ?) OR (priority_rank = ? AND due_at IS NULL AND id > ?))",
+			args:   []any{3, 3, uint(9)},
+		},
+		{
+			name:     "backward from missing due date",
+			values:   []any{3, nil},
+			backward: true,
+			sql: "((priority_rank > ?) OR (priority_rank = ? AND due_at IS NOT NULL) OR " +
+				"(priority_rank = ? AND due_at IS NULL AND id < ?))",
+			args: []any{3, 3, 3, uint(9)},
+		},
+	}
+	for _, tt := range tests {
+		sql, args := keysetCondition(sort, tt.values, 9, tt.backward)
+		if sql != tt.sql {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.sql, sql)
+		}
+		if !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("%s: expected args %v, got %v", tt.name, tt.args, args)
+		}
+	}
+}
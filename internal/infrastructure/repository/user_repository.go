@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/logger"
+
+	"gorm.io/gorm"
+)
+
+type UserRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) domain.UserRepository {
+	return &UserRepositoryImpl{db: db}
+}
+
+func (r *UserRepositoryImpl) Create(ctx context.Context, user *domain.User) error {
+	log := logger.GetLogger()
+	log.Info("Creating user", "username", user.Username, "role", user.Role)
+	err := r.db.WithContext(ctx).Create(user).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return domain.NewConflictError("username already exists")
+	}
+	return err
+}
+
+func (r *UserRepositoryImpl) FindByID(ctx context.Context, id uint) (*domain.User, error) {
+	var user domain.User
+	err := r.db.WithContext(ctx).First(&user, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFoundError("user not found")
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *UserRepositoryImpl) FindByUsername(ctx context.Context, username string) (*domain.User, error) {
+	var user domain.User
+	err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.NewNotFoundError("user not found")
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *UserRepositoryImpl) FindAll(ctx context.Context, page, limit int) ([]domain.User, int64, error) {
+	var users []domain.User
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&domain.User{})
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err = query.Order("username").Offset(offset).Limit(limit).Find(&users).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+func (r *UserRepositoryImpl) Update(ctx context.Context, user *domain.User) error {
+	log := logger.GetLogger()
+	log.Info("Updating user", "id", user.ID, "username", user.Username, "disabled", user.Disabled)
+	return r.db.WithContext(ctx).Save(user).Error
+}
+
+func (r *UserRepositoryImpl) Count(ctx context.Context) (int64, error) {
+	var total int64
+	err := r.db.WithContext(ctx).Model(&domain.User{}).Count(&total).Error
+	return total, err
+}
@@ -0,0 +1,19 @@
+package storage
+
+import (
+	"fmt"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/config"
+)
+
+// NewBlobStore returns the blob store selected by cfg.Driver.
+func NewBlobStore(cfg config.StorageConfig) (domain.BlobStore, error) {
+	switch cfg.Driver {
+	case "local":
+		return NewLocalBlobStore(cfg.LocalPath)
+	case "s3":
+		return NewS3BlobStore(cfg)
+	}
+	return nil, fmt.Errorf("unknown storage driver %q, expected local or s3", cfg.Driver)
+}
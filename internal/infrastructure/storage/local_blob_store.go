@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"task-be/internal/infrastructure/logger"
+)
+
+// LocalBlobStore keeps blobs as files below a root directory, one file per
+// key. Writes go to a temporary file that is renamed into place, so readers
+// never see partial content.
+type LocalBlobStore struct {
+	root string
+}
+
+func NewLocalBlobStore(root string) (*LocalBlobStore, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory %s: %w", root, err)
+	}
+	return &LocalBlobStore{root: root}, nil
+}
+
+func (s *LocalBlobStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	log := logger.GetLogger()
+	log.Info("Storing blob", "key", key, "size", size)
+
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, contextReader{ctx: ctx, r: r}); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *LocalBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, errors.New("blob not found")
+	}
+	return file, err
+}
+
+func (s *LocalBlobStore) Delete(ctx context.Context, key string) error {
+	log := logger.GetLogger()
+	log.Info("Deleting blob", "key", key)
+
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path maps a key to a file below the root, refusing keys that would
+// escape it.
+func (s *LocalBlobStore) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.root, clean), nil
+}
+
+// contextReader stops a copy once ctx is cancelled, e.g. when the client
+// uploading the file goes away.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
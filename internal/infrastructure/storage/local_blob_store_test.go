@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocalBlobStoreRoundTrip(t *testing.T) {
+	store, err := NewLocalBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "tasks/1/abc", strings.NewReader("hello"), 5, "text/plain"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	body, err := store.Get(ctx, "tasks/1/abc")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	content, _ := io.ReadAll(body)
+	body.Close()
+	if string(content) != "hello" {
+		t.Errorf("Expected hello, got %q", content)
+	}
+
+	if err := store.Delete(ctx, "tasks/1/abc"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := store.Delete(ctx, "tasks/1/abc"); err != nil {
+		t.Errorf("Expected deleting a missing blob to succeed, got %v", err)
+	}
+	if _, err := store.Get(ctx, "tasks/1/abc"); err == nil || err.Error() != "blob not found" {
+		t.Errorf("Expected blob not found error, got %v", err)
+	}
+}
+
+func TestLocalBlobStoreRejectsKeysOutsideRoot(t *testing.T) {
+	store, _ := NewLocalBlobStore(t.TempDir())
+
+	for _, key := range []string{"", "../escape", "/etc/passwd", "tasks/../../escape"} {
+		if err := store.Put(context.Background(), key, strings.NewReader("x"), 1, ""); err == nil {
+			t.Errorf("Expected error for key %q", key)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"task-be/internal/infrastructure/config"
+	"task-be/internal/infrastructure/logger"
+)
+
+// S3BlobStore keeps blobs in a bucket of an S3-compatible service. Requests
+// use path-style addressing (endpoint/bucket/key) so it works with MinIO and
+// other stand-ins without DNS setup, and are signed with Signature V4.
+type S3BlobStore struct {
+	endpoint  *url.URL
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+	now       func() time.Time
+}
+
+func NewS3BlobStore(cfg config.StorageConfig) (*S3BlobStore, error) {
+	endpoint, err := url.Parse(cfg.S3Endpoint)
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", cfg.S3Endpoint)
+	}
+	if cfg.S3Bucket == "" {
+		return nil, errors.New("S3 bucket is not configured")
+	}
+	if cfg.S3AccessKey == "" || cfg.S3SecretKey == "" {
+		return nil, errors.New("S3 credentials are not configured")
+	}
+
+	return &S3BlobStore{
+		endpoint:  endpoint,
+		bucket:    cfg.S3Bucket,
+		region:    cfg.S3Region,
+		accessKey: cfg.S3AccessKey,
+		secretKey: cfg.S3SecretKey,
+		client:    &http.Client{},
+		now:       time.Now,
+	}, nil
+}
+
+func (s *S3BlobStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	log := logger.GetLogger()
+	log.Info("Storing blob", "key", key, "size", size, "bucket", s.bucket)
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, r)
+	if err != nil {
+		return err
+	}
+	// S3 rejects chunked uploads without a length.
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.do(req, unsignedPayload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s3Error("put", key, resp)
+	}
+	return nil
+}
+
+func (s *S3BlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req, emptyPayload)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, errors.New("blob not found")
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, s3Error("get", key, resp)
+	}
+	return resp.Body, nil
+}
+
+func (s *S3BlobStore) Delete(ctx context.Context, key string) error {
+	log := logger.GetLogger()
+	log.Info("Deleting blob", "key", key, "bucket", s.bucket)
+
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req, emptyPayload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return s3Error("delete", key, resp)
+	}
+	return nil
+}
+
+func (s *S3BlobStore) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return nil, fmt.Errorf("invalid blob key %q", key)
+	}
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.bucket + "/" + key
+	u.RawPath = ""
+	return http.NewRequestWithContext(ctx, method, u.String(), body)
+}
+
+func (s *S3BlobStore) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	signV4(req, payloadHash, s.accessKey, s.secretKey, s.region, s.now())
+	return s.client.Do(req)
+}
+
+// s3Error turns an unexpected response into an error carrying the status and
+// the start of the XML error document.
+func s3Error(op, key string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("s3 %s %s failed with status %d: %s", op, key, resp.StatusCode, strings.TrimSpace(string(body)))
+}
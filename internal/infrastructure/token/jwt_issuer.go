@@ -0,0 +1,124 @@
+package token
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/config"
+	"task-be/internal/infrastructure/logger"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWTIssuer signs access tokens with HS256 using the active key and verifies
+// tokens against every configured key, selected by the "kid" header. Rotating
+// keys means adding a new key, making it active, and removing the old one once
+// the tokens it signed have expired.
+type JWTIssuer struct {
+	keys        map[string][]byte
+	activeKeyID string
+	issuer      string
+	ttl         time.Duration
+}
+
+type accessClaims struct {
+	Username string `json:"username"`
+	jwt.RegisteredClaims
+}
+
+func NewJWTIssuer(cfg config.JWTConfig) (*JWTIssuer, error) {
+	keys := make(map[string][]byte, len(cfg.SigningKeys))
+	for kid, secret := range cfg.SigningKeys {
+		keys[kid] = []byte(secret)
+	}
+
+	activeKeyID := cfg.ActiveKeyID
+	if len(keys) == 0 {
+		if !cfg.AllowEphemeralKey {
+			return nil, errors.New("no JWT signing keys configured; set JWT_SIGNING_KEYS")
+		}
+		log := logger.GetLogger()
+		log.Warn("No JWT signing keys configured, using an ephemeral key; tokens will not survive a restart")
+
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		activeKeyID = "ephemeral-" + hex.EncodeToString(secret[:4])
+		keys[activeKeyID] = secret
+	}
+
+	if activeKeyID == "" && len(keys) == 1 {
+		for kid := range keys {
+			activeKeyID = kid
+		}
+	}
+	if _, ok := keys[activeKeyID]; !ok {
+		return nil, fmt.Errorf("active JWT key %q is not among the configured signing keys", activeKeyID)
+	}
+
+	return &JWTIssuer{
+		keys:        keys,
+		activeKeyID: activeKeyID,
+		issuer:      cfg.Issuer,
+		ttl:         cfg.AccessTokenTTL,
+	}, nil
+}
+
+func (i *JWTIssuer) IssueAccessToken(user *domain.User) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(i.ttl)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims{
+		Username: user.Username,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    i.issuer,
+			Subject:   strconv.FormatUint(uint64(user.ID), 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+	token.Header["kid"] = i.activeKeyID
+
+	signed, err := token.SignedString(i.keys[i.activeKeyID])
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+func (i *JWTIssuer) ParseAccessToken(tokenString string) (*domain.AccessTokenClaims, error) {
+	var claims accessClaims
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := i.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		return key, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(i.issuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, domain.NewUnauthenticatedError("invalid access token")
+	}
+
+	userID, err := strconv.ParseUint(claims.Subject, 10, 32)
+	if err != nil {
+		return nil, domain.NewUnauthenticatedError("invalid access token")
+	}
+
+	return &domain.AccessTokenClaims{
+		UserID:    uint(userID),
+		Username:  claims.Username,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/config"
+)
+
+func newTestIssuer(t *testing.T, keys map[string]string, active string) *JWTIssuer {
+	t.Helper()
+	issuer, err := NewJWTIssuer(config.JWTConfig{
+		SigningKeys:    keys,
+		ActiveKeyID:    active,
+		Issuer:         "task-be",
+		AccessTokenTTL: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	return issuer
+}
+
+func TestIssueAndParseAccessToken(t *testing.T) {
+	issuer := newTestIssuer(t, map[string]string{"k1": "secret-one"}, "k1")
+
+	signed, _, err := issuer.IssueAccessToken(&domain.User{ID: 42, Username: "alice"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	claims, err := issuer.ParseAccessToken(signed)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if claims.UserID != 42 || claims.Username != "alice" {
+		t.Errorf("Unexpected claims %+v", claims)
+	}
+}
+
+func TestKeyRotationKeepsOldTokensValid(t *testing.T) {
+	before := newTestIssuer(t, map[string]string{"k1": "secret-one"}, "k1")
+	oldToken, _, _ := before.IssueAccessToken(&domain.User{ID: 1, Username: "alice"})
+
+	rotated := newTestIssuer(t, map[string]string{"k1": "secret-one", "k2": "secret-two"}, "k2")
+	if _, err := rotated.ParseAccessToken(oldToken); err != nil {
+		t.Errorf("Expected token signed with previous key to stay valid, got %v", err)
+	}
+
+	retired := newTestIssuer(t, map[string]string{"k2": "secret-two"}, "k2")
+	if _, err := retired.ParseAccessToken(oldToken); err == nil {
+		t.Error("Expected token signed with a removed key to be rejected")
+	}
+}
+
+func TestParseRejectsExpiredToken(t *testing.T) {
+	issuer := newTestIssuer(t, map[string]string{"k1": "secret-one"}, "k1")
+	issuer.ttl = -time.Minute
+
+	expired, _, _ := issuer.IssueAccessToken(&domain.User{ID: 1, Username: "alice"})
+	if _, err := issuer.ParseAccessToken(expired); err == nil {
+		t.Error("Expected expired token to be rejected")
+	}
+}
+
+func TestNewJWTIssuerRejectsUnknownActiveKey(t *testing.T) {
+	_, err := NewJWTIssuer(config.JWTConfig{
+		SigningKeys: map[string]string{"k1": "secret-one"},
+		ActiveKeyID: "k2",
+	})
+	if err == nil {
+		t.Error("Expected error for unknown active key")
+	}
+}
+
+func TestNewJWTIssuerRequiresSigningKeys(t *testing.T) {
+	if _, err := NewJWTIssuer(config.JWTConfig{Issuer: "task-be"}); err == nil {
+		t.Error("Expected error without signing keys")
+	}
+
+	issuer, err := NewJWTIssuer(config.JWTConfig{Issuer: "task-be", AccessTokenTTL: time.Minute, AllowEphemeralKey: true})
+	if err != nil {
+		t.Fatalf("Expected an ephemeral key when allowed, got %v", err)
+	}
+	signed, _, _ := issuer.IssueAccessToken(&domain.User{ID: 1, Username: "alice"})
+	if _, err := issuer.ParseAccessToken(signed); err != nil {
+		t.Errorf("Expected token signed with the ephemeral key to be valid, got %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+package dto
+
+type AttachmentResponse struct {
+	ID          uint   `json:"id"`
+	TaskID      uint   `json:"task_id"`
+	UploadedBy  uint   `json:"uploaded_by"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Checksum    string `json:"checksum"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type AttachmentListResponse struct {
+	Attachments []AttachmentResponse `json:"attachments"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+type CreateCommentRequest struct {
+	Body string `json:"body" validate:"required,max=10000"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body" validate:"required,max=10000"`
+}
+
+type CommentResponse struct {
+	ID        uint    `json:"id"`
+	TaskID    uint    `json:"task_id"`
+	AuthorID  uint    `json:"author_id"`
+	Body      string  `json:"body"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+	EditedAt  *string `json:"edited_at"`
+}
+
+type CommentListResponse struct {
+	Comments []CommentResponse `json:"comments"`
+	Total    int64             `json:"total"`
+	Page     int               `json:"page"`
+	Limit    int               `json:"limit"`
+}
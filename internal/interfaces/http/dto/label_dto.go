@@ -0,0 +1,23 @@
+package dto
+
+type CreateLabelRequest struct {
+	Name  string `json:"name" validate:"required,max=50"`
+	Color string `json:"color" validate:"omitempty,hexcolor"`
+}
+
+type UpdateLabelRequest struct {
+	Name  *string `json:"name" validate:"omitempty,max=50"`
+	Color *string `json:"color" validate:"omitempty,hexcolor"`
+}
+
+type LabelResponse struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	Color     string `json:"color"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type LabelListResponse struct {
+	Labels []LabelResponse `json:"labels"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+// ProblemResponse is an RFC 9457 problem details document, the body of every
+// error response.
+type ProblemResponse struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	// Errors lists the invalid fields of a validation problem.
+	Errors []FieldErrorResponse `json:"errors,omitempty"`
+}
+
+type FieldErrorResponse struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// TransitionProblemResponse is the problem sent for an invalid status
+// transition, extended with the transitions that are allowed instead.
+type TransitionProblemResponse struct {
+	ProblemResponse
+	CurrentStatus      string   `json:"current_status"`
+	RequestedStatus    string   `json:"requested_status"`
+	AllowedTransitions []string `json:"allowed_transitions"`
+}
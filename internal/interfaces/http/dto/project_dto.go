@@ -0,0 +1,30 @@
+package dto
+
+type CreateProjectRequest struct {
+	Key         string `json:"key" validate:"required,min=2,max=10"`
+	Name        string `json:"name" validate:"required,max=255"`
+	Description string `json:"description"`
+}
+
+type UpdateProjectRequest struct {
+	Name        *string `json:"name" validate:"omitempty,max=255"`
+	Description *string `json:"description"`
+	Archived    *bool   `json:"archived"`
+}
+
+type ProjectResponse struct {
+	ID          uint   `json:"id"`
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Archived    bool   `json:"archived"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+type ProjectListResponse struct {
+	Projects []ProjectResponse `json:"projects"`
+	Total    int64             `json:"total"`
+	Page     int               `json:"page"`
+	Limit    int               `json:"limit"`
+}
@@ -1,28 +1,183 @@
 package dto
 
+import (
+	"encoding/json"
+
+	"task-be/internal/domain"
+)
+
 type CreateTaskRequest struct {
 	Title       string `json:"title" validate:"required,max=255"`
 	Description string `json:"description"`
+	Priority    string `json:"priority" validate:"omitempty,task_priority"`
+	// StartAt and DueAt take an RFC3339 timestamp; an empty string means no
+	// date.
+	StartAt   *string `json:"start_at"`
+	DueAt     *string `json:"due_at"`
+	ParentID  *uint   `json:"parent_id"`
+	ProjectID *uint   `json:"project_id"`
 }
 
 type UpdateTaskRequest struct {
-	Title       *string `json:"title" validate:"omitempty,max=255"`
+	Title       *string `json:"title" validate:"omitnil,min=1,max=255"`
 	Description *string `json:"description"`
-	Status      *string `json:"status" validate:"omitempty,oneof=TO_DO IN_PROGRESS DONE"`
+	Status      *string `json:"status" validate:"omitempty,task_status"`
+	Priority    *string `json:"priority" validate:"omitempty,task_priority"`
+	// StartAt and DueAt take an RFC3339 timestamp; an empty string clears the date.
+	StartAt *string `json:"start_at"`
+	DueAt   *string `json:"due_at"`
+	// ParentID moves the task under another task; 0 detaches it.
+	ParentID *uint `json:"parent_id"`
+}
+
+// Normalize cleans up the title before the request is validated.
+func (r *CreateTaskRequest) Normalize() {
+	r.Title = domain.NormalizeTaskTitle(r.Title)
+}
+
+// Normalize cleans up the title before the request is validated. A title
+// that is only whitespace becomes empty and is rejected.
+func (r *UpdateTaskRequest) Normalize() {
+	if r.Title != nil {
+		title := domain.NormalizeTaskTitle(*r.Title)
+		r.Title = &title
+	}
+}
+
+// BulkTaskRequest runs several task operations at once. Unless Atomic is
+// false, either all of them are applied or none is.
+type BulkTaskRequest struct {
+	Atomic     *bool                      `json:"atomic"`
+	Operations []BulkTaskOperationRequest `json:"operations"`
+}
+
+// BulkTaskOperationRequest is a create, update or delete. Task holds a
+// CreateTaskRequest or an UpdateTaskRequest; Version guards an update or
+// delete like If-Match does.
+type BulkTaskOperationRequest struct {
+	Op      string          `json:"op"`
+	ID      uint            `json:"id"`
+	Version *uint           `json:"version"`
+	Task    json.RawMessage `json:"task"`
+}
+
+type AssignTaskRequest struct {
+	AssigneeID uint `json:"assignee_id" validate:"required"`
+}
+
+type AddTaskDependencyRequest struct {
+	BlockedByID uint `json:"blocked_by_id" validate:"required"`
 }
 
 type TaskResponse struct {
-	ID          uint   `json:"id"`
+	ID           uint                 `json:"id"`
+	Key          *string              `json:"key"`
+	ProjectID    *uint                `json:"project_id"`
+	Title        string               `json:"title"`
+	Description  string               `json:"description"`
+	Status       string               `json:"status"`
+	Priority     string               `json:"priority"`
+	StartAt      *string              `json:"start_at"`
+	DueAt        *string              `json:"due_at"`
+	CreatedBy    *uint                `json:"created_by"`
+	AssigneeID   *uint                `json:"assignee_id"`
+	ParentID     *uint                `json:"parent_id"`
+	Progress     TaskProgressResponse `json:"progress"`
+	Labels       []LabelResponse      `json:"labels"`
+	IsOverdue    bool                 `json:"is_overdue"`
+	IsBlocked    bool                 `json:"blocked"`
+	CommentCount int64                `json:"comment_count"`
+	Version      uint                 `json:"version"`
+	CreatedAt    string               `json:"created_at"`
+	UpdatedAt    string               `json:"updated_at"`
+	DeletedAt    *string              `json:"deleted_at,omitempty"`
+}
+
+// BulkTaskResponse holds one result per operation, in request order.
+type BulkTaskResponse struct {
+	Atomic  bool                     `json:"atomic"`
+	Results []BulkTaskResultResponse `json:"results"`
+}
+
+// BulkTaskResultResponse carries the status code the operation would have
+// received on its own, and the task it created or updated or the reason it
+// failed.
+type BulkTaskResultResponse struct {
+	Op     string        `json:"op"`
+	ID     *uint         `json:"id,omitempty"`
+	Status int           `json:"status"`
+	Task   *TaskResponse `json:"task,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+type TaskProgressResponse struct {
+	Done    int64 `json:"done"`
+	Total   int64 `json:"total"`
+	Percent int   `json:"percent"`
+}
+
+// TaskListResponse is a page of tasks. Page-number pagination, the default,
+// fills Page and Total; cursor pagination fills NextCursor and PrevCursor,
+// and Total only on request.
+type TaskListResponse struct {
+	Tasks      []TaskResponse `json:"tasks"`
+	Total      *int64         `json:"total,omitempty"`
+	Page       int            `json:"page,omitempty"`
+	Limit      int            `json:"limit"`
+	NextCursor *string        `json:"next_cursor"`
+	PrevCursor *string        `json:"prev_cursor"`
+}
+
+// TaskSearchResultResponse is a task with its search relevance. Highlights
+// are HTML with matching words wrapped in <mark> tags.
+type TaskSearchResultResponse struct {
+	TaskResponse
+	Rank      float64               `json:"rank"`
+	Highlight TaskHighlightResponse `json:"highlight"`
+}
+
+type TaskHighlightResponse struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
-	Status      string `json:"status"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
 }
 
-type TaskListResponse struct {
-	Tasks []TaskResponse `json:"tasks"`
-	Total int64         `json:"total"`
-	Page  int           `json:"page"`
-	Limit int           `json:"limit"`
+type TaskSearchResponse struct {
+	Results []TaskSearchResultResponse `json:"results"`
+	Total   int64                      `json:"total"`
+	Page    int                        `json:"page"`
+	Limit   int                        `json:"limit"`
+}
+
+type TaskDependenciesResponse struct {
+	TaskID    uint           `json:"task_id"`
+	BlockedBy []TaskResponse `json:"blocked_by"`
+	Blocks    []TaskResponse `json:"blocks"`
+}
+
+type TaskTransitionsResponse struct {
+	TaskID             uint     `json:"task_id"`
+	CurrentStatus      string   `json:"current_status"`
+	AllowedTransitions []string `json:"allowed_transitions"`
+}
+
+type FieldChangeResponse struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+type TaskEventResponse struct {
+	ID        uint                  `json:"id"`
+	TaskID    uint                  `json:"task_id"`
+	Type      string                `json:"type"`
+	Actor     string                `json:"actor"`
+	Changes   []FieldChangeResponse `json:"changes"`
+	CreatedAt string                `json:"created_at"`
+}
+
+type TaskHistoryResponse struct {
+	Events []TaskEventResponse `json:"events"`
+	Total  int64               `json:"total"`
+	Page   int                 `json:"page"`
+	Limit  int                 `json:"limit"`
 }
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"task-be/internal/domain"
+	"task-be/internal/interfaces/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+// multipartOverhead is the allowance for multipart headers and boundaries on
+// top of the maximum attachment size.
+const multipartOverhead = 64 << 10
+
+type AttachmentHandler struct {
+	attachmentService domain.AttachmentService
+	authorizer        domain.Authorizer
+	maxSize           int64
+}
+
+func NewAttachmentHandler(attachmentService domain.AttachmentService, authorizer domain.Authorizer, maxSize int64) *AttachmentHandler {
+	return &AttachmentHandler{attachmentService: attachmentService, authorizer: authorizer, maxSize: maxSize}
+}
+
+// MaxRequestSize is the largest upload request the handler accepts.
+func (h *AttachmentHandler) MaxRequestSize() int64 {
+	return h.maxSize + multipartOverhead
+}
+
+func (h *AttachmentHandler) UploadAttachment(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskUpdate); err != nil {
+		return err
+	}
+
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
+	}
+
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, h.MaxRequestSize())
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "Attachment too large")
+		}
+		return echo.NewHTTPError(http.StatusBadRequest, "Expected a multipart upload with a file field")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid upload")
+	}
+	defer file.Close()
+
+	attachment, err := h.attachmentService.UploadAttachment(c.Request().Context(), uint(taskID), domain.UploadAttachmentInput{
+		Filename:    fileHeader.Filename,
+		ContentType: fileHeader.Header.Get(echo.HeaderContentType),
+		Size:        fileHeader.Size,
+		Content:     file,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrAttachmentTooLarge) {
+			return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "Attachment too large")
+		}
+		if errors.Is(err, domain.ErrAttachmentTypeNotAllowed) {
+			return echo.NewHTTPError(http.StatusUnsupportedMediaType, "Attachment type not allowed")
+		}
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, toAttachmentResponse(attachment))
+}
+
+func (h *AttachmentHandler) GetAttachments(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskRead); err != nil {
+		return err
+	}
+
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
+	}
+
+	attachments, err := h.attachmentService.GetAttachments(c.Request().Context(), uint(taskID))
+	if err != nil {
+		return err
+	}
+
+	responses := make([]dto.AttachmentResponse, 0, len(attachments))
+	for i := range attachments {
+		responses = append(responses, toAttachmentResponse(&attachments[i]))
+	}
+
+	return c.JSON(http.StatusOK, dto.AttachmentListResponse{Attachments: responses})
+}
+
+// DownloadAttachment streams the content of an attachment. It is always
+// served as a download so uploaded HTML or SVG never runs in the API origin.
+func (h *AttachmentHandler) DownloadAttachment(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskRead); err != nil {
+		return err
+	}
+
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
+	}
+
+	attachmentID, err := strconv.ParseUint(c.Param("attachmentId"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid attachment ID")
+	}
+
+	attachment, content, err := h.attachmentService.OpenAttachment(c.Request().Context(), uint(taskID), uint(attachmentID))
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	header.Set(echo.HeaderContentLength, strconv.FormatInt(attachment.Size, 10))
+	header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+	header.Set("ETag", `"`+attachment.Checksum+`"`)
+	if sum, err := hex.DecodeString(attachment.Checksum); err == nil {
+		header.Set("Digest", "sha-256="+base64.StdEncoding.EncodeToString(sum))
+	}
+
+	return c.Stream(http.StatusOK, attachment.ContentType, content)
+}
+
+func (h *AttachmentHandler) DeleteAttachment(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskUpdate); err != nil {
+		return err
+	}
+
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
+	}
+
+	attachmentID, err := strconv.ParseUint(c.Param("attachmentId"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid attachment ID")
+	}
+
+	err = h.attachmentService.DeleteAttachment(c.Request().Context(), uint(taskID), uint(attachmentID))
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func toAttachmentResponse(attachment *domain.Attachment) dto.AttachmentResponse {
+	return dto.AttachmentResponse{
+		ID:          attachment.ID,
+		TaskID:      attachment.TaskID,
+		UploadedBy:  attachment.UploadedBy,
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Checksum:    attachment.Checksum,
+		CreatedAt:   attachment.CreatedAt.Format(time.RFC3339),
+	}
+}
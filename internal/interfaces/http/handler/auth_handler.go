@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"task-be/internal/domain"
+	"task-be/internal/interfaces/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type AuthHandler struct {
+	authService domain.AuthService
+}
+
+func NewAuthHandler(authService domain.AuthService) *AuthHandler {
+	return &AuthHandler{authService: authService}
+}
+
+func (h *AuthHandler) Login(c echo.Context) error {
+	var req dto.LoginRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	tokens, err := h.authService.Login(c.Request().Context(), req.Username, req.Password)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid username or password")
+		}
+		return err
+	}
+
+	return c.JSON(http.StatusOK, toTokenResponse(tokens))
+}
+
+func (h *AuthHandler) Refresh(c echo.Context) error {
+	var req dto.RefreshTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	tokens, err := h.authService.Refresh(c.Request().Context(), req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid refresh token")
+		}
+		return err
+	}
+
+	return c.JSON(http.StatusOK, toTokenResponse(tokens))
+}
+
+func (h *AuthHandler) Logout(c echo.Context) error {
+	var req dto.RefreshTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	err := h.authService.Logout(c.Request().Context(), req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, domain.ErrUnauthenticated) {
+			return echo.NewHTTPError(http.StatusUnauthorized, "Invalid refresh token")
+		}
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func toTokenResponse(tokens *domain.TokenPair) dto.TokenResponse {
+	return dto.TokenResponse{
+		AccessToken:           tokens.AccessToken,
+		TokenType:             "Bearer",
+		ExpiresIn:             int64(time.Until(tokens.AccessTokenExpiresAt).Seconds()),
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"errors"
+
+	"task-be/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// authorize consults the authorizer for the caller attached to the request
+// context. Anonymous callers are challenged with 401, authenticated callers
+// lacking the permission get 403.
+func authorize(c echo.Context, authorizer domain.Authorizer, permission domain.Permission) error {
+	err := authorizer.Authorize(c.Request().Context(), permission)
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, domain.ErrUnauthenticated) {
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="Restricted"`)
+	}
+	return err
+}
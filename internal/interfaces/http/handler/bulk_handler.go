@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/logger"
+	"task-be/internal/interfaces/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+// BulkTasks runs a batch of create, update and delete operations in a single
+// transaction and reports a result per operation. When an atomic batch fails,
+// the response carries the status of the failing operation.
+func (h *TaskHandler) BulkTasks(c echo.Context) error {
+	var req dto.BulkTaskRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	if len(req.Operations) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, "At least one operation is required")
+	}
+	if len(req.Operations) > domain.MaxBulkOperations {
+		return echo.NewHTTPError(http.StatusBadRequest, "At most "+strconv.Itoa(domain.MaxBulkOperations)+" operations are allowed")
+	}
+
+	force, err := parseForce(c)
+	if err != nil {
+		return err
+	}
+
+	operations := make([]domain.BulkTaskOperation, len(req.Operations))
+	for i, op := range req.Operations {
+		operations[i], err = parseBulkTaskOperation(c, i, op)
+		if err != nil {
+			return err
+		}
+		if operations[i].Type == domain.BulkOperationUpdate {
+			operations[i].Update.Force = force
+		}
+	}
+
+	// Every kind of operation in the batch needs its own permission.
+	permissions := map[domain.BulkOperationType]domain.Permission{
+		domain.BulkOperationCreate: domain.PermissionTaskCreate,
+		domain.BulkOperationUpdate: domain.PermissionTaskUpdate,
+		domain.BulkOperationDelete: domain.PermissionTaskDelete,
+	}
+	checked := make(map[domain.BulkOperationType]bool)
+	for _, operation := range operations {
+		if checked[operation.Type] {
+			continue
+		}
+		if err := authorize(c, h.authorizer, permissions[operation.Type]); err != nil {
+			return err
+		}
+		checked[operation.Type] = true
+	}
+
+	atomic := req.Atomic == nil || *req.Atomic
+	results, err := h.taskService.BulkTasks(c.Request().Context(), operations, atomic)
+	if err != nil {
+		return err
+	}
+
+	return respondWithBulkResults(c, operations, results, atomic)
+}
+
+// UpdateTasks applies the same update, typically a status change, to every
+// task listed in the ids query parameter.
+func (h *TaskHandler) UpdateTasks(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskUpdate); err != nil {
+		return err
+	}
+
+	ids, err := parseTaskIDs(c.QueryParam("ids"))
+	if err != nil {
+		return err
+	}
+	atomic := true
+	if a := c.QueryParam("atomic"); a != "" {
+		atomic, err = strconv.ParseBool(a)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid atomic, expected true or false")
+		}
+	}
+
+	var req dto.UpdateTaskRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	input, err := toUpdateTaskInput(req)
+	if err != nil {
+		return err
+	}
+	if input.Force, err = parseForce(c); err != nil {
+		return err
+	}
+
+	results, err := h.taskService.UpdateTasks(c.Request().Context(), ids, input, atomic)
+	if err != nil {
+		return err
+	}
+
+	operations := make([]domain.BulkTaskOperation, len(ids))
+	for i, id := range ids {
+		operations[i] = domain.BulkTaskOperation{Type: domain.BulkOperationUpdate, ID: id, Update: input}
+	}
+	return respondWithBulkResults(c, operations, results, atomic)
+}
+
+// parseBulkTaskOperation checks the shape of one operation. A malformed
+// operation fails the whole request, whatever the mode.
+func parseBulkTaskOperation(c echo.Context, index int, req dto.BulkTaskOperationRequest) (domain.BulkTaskOperation, error) {
+	operation := domain.BulkTaskOperation{Type: domain.BulkOperationType(req.Op), ID: req.ID, Version: req.Version}
+	invalid := func(message string) error {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid operation %d: %s", index, message))
+	}
+
+	switch operation.Type {
+	case domain.BulkOperationCreate:
+		if req.ID != 0 || req.Version != nil {
+			return operation, invalid("create does not take an id or version")
+		}
+		if len(req.Task) == 0 {
+			return operation, invalid("task is required")
+		}
+		var task dto.CreateTaskRequest
+		if err := json.Unmarshal(req.Task, &task); err != nil {
+			return operation, invalid("invalid task")
+		}
+		if err := c.Validate(&task); err != nil {
+			return operation, invalidOperationTask(index, err)
+		}
+		input, err := toCreateTaskInput(task)
+		if err != nil {
+			return operation, invalidOperationTask(index, err)
+		}
+		operation.Create = input
+	case domain.BulkOperationUpdate:
+		if req.ID == 0 {
+			return operation, invalid("id is required")
+		}
+		if len(req.Task) == 0 {
+			return operation, invalid("task is required")
+		}
+		var task dto.UpdateTaskRequest
+		if err := json.Unmarshal(req.Task, &task); err != nil {
+			return operation, invalid("invalid task")
+		}
+		if err := c.Validate(&task); err != nil {
+			return operation, invalidOperationTask(index, err)
+		}
+		input, err := toUpdateTaskInput(task)
+		if err != nil {
+			return operation, invalidOperationTask(index, err)
+		}
+		input.Version = req.Version
+		operation.Update = input
+	case domain.BulkOperationDelete:
+		if req.ID == 0 {
+			return operation, invalid("id is required")
+		}
+		if len(req.Task) != 0 {
+			return operation, invalid("delete does not take a task")
+		}
+	default:
+		return operation, invalid("op must be create, update or delete")
+	}
+	return operation, nil
+}
+
+// invalidOperationTask reports the invalid fields of the task of an
+// operation by their path in the request body.
+func invalidOperationTask(index int, err error) error {
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) {
+		return err
+	}
+	fields := make([]domain.FieldError, 0, len(domainErr.Fields))
+	for _, field := range domainErr.Fields {
+		field.Field = fmt.Sprintf("operations[%d].task.%s", index, field.Field)
+		fields = append(fields, field)
+	}
+	return domain.NewValidationError(fmt.Sprintf("Invalid operation %d: task is invalid", index), fields...)
+}
+
+// parseTaskIDs parses a comma-separated list of task IDs.
+func parseTaskIDs(value string) ([]uint, error) {
+	if value == "" {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "ids is required")
+	}
+	parts := strings.Split(value, ",")
+	if len(parts) > domain.MaxBulkOperations {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "At most "+strconv.Itoa(domain.MaxBulkOperations)+" ids are allowed")
+	}
+	ids := make([]uint, 0, len(parts))
+	for _, part := range parts {
+		id, err := strconv.ParseUint(strings.TrimSpace(part), 10, 32)
+		if err != nil || id == 0 {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid ids, expected comma-separated task IDs")
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
+
+func respondWithBulkResults(c echo.Context, operations []domain.BulkTaskOperation, results []domain.BulkTaskResult, atomic bool) error {
+	status := http.StatusOK
+	response := dto.BulkTaskResponse{
+		Atomic:  atomic,
+		Results: make([]dto.BulkTaskResultResponse, 0, len(results)),
+	}
+	for i, result := range results {
+		operation := operations[i]
+		item := dto.BulkTaskResultResponse{Op: string(operation.Type)}
+		if operation.Type != domain.BulkOperationCreate {
+			id := operation.ID
+			item.ID = &id
+		}
+
+		if result.Err != nil {
+			item.Status = bulkOperationStatus(operation, result.Err)
+			item.Error = result.Err.Error()
+			if item.Status >= http.StatusInternalServerError {
+				log := logger.GetLogger()
+				log.Error("Bulk task operation failed", "error", result.Err, "op", operation.Type, "id", operation.ID,
+					"request_id", c.Response().Header().Get(echo.HeaderXRequestID))
+				item.Error = http.StatusText(item.Status)
+			}
+			if atomic && item.Status != http.StatusFailedDependency {
+				status = item.Status
+			}
+			response.Results = append(response.Results, item)
+			continue
+		}
+
+		switch operation.Type {
+		case domain.BulkOperationCreate:
+			item.Status = http.StatusCreated
+		case domain.BulkOperationUpdate:
+			item.Status = http.StatusOK
+		case domain.BulkOperationDelete:
+			item.Status = http.StatusNoContent
+		}
+		if result.Task != nil {
+			task := toTaskResponse(result.Task)
+			item.ID = &task.ID
+			item.Task = &task
+		}
+		response.Results = append(response.Results, item)
+	}
+
+	return c.JSON(status, response)
+}
+
+// bulkOperationStatus is the status code the failed operation would have
+// received as a request of its own. Operations rolled back because another
+// one failed get 424 Failed Dependency.
+func bulkOperationStatus(operation domain.BulkTaskOperation, err error) int {
+	if errors.Is(err, domain.ErrBatchAborted) {
+		return http.StatusFailedDependency
+	}
+	if errors.Is(err, domain.ErrVersionMismatch) && (operation.Version != nil || operation.Update.Version != nil) {
+		return http.StatusPreconditionFailed
+	}
+	status, _ := problemStatus(err)
+	return status
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"task-be/internal/domain"
+	"task-be/internal/interfaces/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CommentHandler struct {
+	commentService domain.CommentService
+	authorizer     domain.Authorizer
+}
+
+func NewCommentHandler(commentService domain.CommentService, authorizer domain.Authorizer) *CommentHandler {
+	return &CommentHandler{commentService: commentService, authorizer: authorizer}
+}
+
+func (h *CommentHandler) CreateComment(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionCommentCreate); err != nil {
+		return err
+	}
+
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
+	}
+
+	var req dto.CreateCommentRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	comment, err := h.commentService.CreateComment(c.Request().Context(), uint(taskID), req.Body)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, toCommentResponse(comment))
+}
+
+func (h *CommentHandler) GetComments(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskRead); err != nil {
+		return err
+	}
+
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
+	}
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+	comments, total, err := h.commentService.GetComments(c.Request().Context(), uint(taskID), page, limit)
+	if err != nil {
+		return err
+	}
+
+	commentResponses := make([]dto.CommentResponse, 0, len(comments))
+	for i := range comments {
+		commentResponses = append(commentResponses, toCommentResponse(&comments[i]))
+	}
+
+	response := dto.CommentListResponse{
+		Comments: commentResponses,
+		Total:    total,
+		Page:     page,
+		Limit:    limit,
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *CommentHandler) UpdateComment(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionCommentCreate); err != nil {
+		return err
+	}
+
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
+	}
+
+	commentID, err := strconv.ParseUint(c.Param("commentId"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid comment ID")
+	}
+
+	var req dto.UpdateCommentRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	comment, err := h.commentService.UpdateComment(c.Request().Context(), uint(taskID), uint(commentID), req.Body)
+	if err != nil {
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			return echo.NewHTTPError(http.StatusForbidden, "Only the author or an admin can change this comment")
+		}
+		return err
+	}
+
+	return c.JSON(http.StatusOK, toCommentResponse(comment))
+}
+
+func (h *CommentHandler) DeleteComment(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionCommentCreate); err != nil {
+		return err
+	}
+
+	taskID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
+	}
+
+	commentID, err := strconv.ParseUint(c.Param("commentId"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid comment ID")
+	}
+
+	err = h.commentService.DeleteComment(c.Request().Context(), uint(taskID), uint(commentID))
+	if err != nil {
+		if errors.Is(err, domain.ErrPermissionDenied) {
+			return echo.NewHTTPError(http.StatusForbidden, "Only the author or an admin can change this comment")
+		}
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func toCommentResponse(comment *domain.Comment) dto.CommentResponse {
+	return dto.CommentResponse{
+		ID:        comment.ID,
+		TaskID:    comment.TaskID,
+		AuthorID:  comment.AuthorID,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: comment.UpdatedAt.Format(time.RFC3339),
+		EditedAt:  formatOptionalTime(comment.EditedAt),
+	}
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"task-be/internal/interfaces/http/openapi"
+
+	"github.com/labstack/echo/v4"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+// docsPage loads the bundled Swagger UI and points it at /openapi.json.
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+  <head>
+    <meta charset="UTF-8">
+    <title>Task Management API</title>
+    <link rel="stylesheet" type="text/css" href="/docs/swagger-ui.css" />
+    <link rel="stylesheet" type="text/css" href="/docs/index.css" />
+    <link rel="icon" type="image/png" href="/docs/favicon-32x32.png" sizes="32x32" />
+    <link rel="icon" type="image/png" href="/docs/favicon-16x16.png" sizes="16x16" />
+  </head>
+  <body>
+    <div id="swagger-ui"></div>
+    <script src="/docs/swagger-ui-bundle.js" charset="UTF-8"></script>
+    <script src="/docs/swagger-ui-standalone-preset.js" charset="UTF-8"></script>
+    <script>
+      window.onload = function () {
+        window.ui = SwaggerUIBundle({
+          url: "/openapi.json",
+          dom_id: "#swagger-ui",
+          deepLinking: true,
+          presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+          layout: "StandaloneLayout"
+        });
+      };
+    </script>
+  </body>
+</html>
+`
+
+// DocsHandler serves the OpenAPI document and a Swagger UI to browse it.
+// The UI is embedded in the binary, so the docs work without internet
+// access.
+type DocsHandler struct {
+	spec []byte
+}
+
+func NewDocsHandler(spec *openapi.Document) (*DocsHandler, error) {
+	body, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	return &DocsHandler{spec: body}, nil
+}
+
+func (h *DocsHandler) GetOpenAPISpec(c echo.Context) error {
+	return c.JSONBlob(http.StatusOK, h.spec)
+}
+
+func (h *DocsHandler) GetDocs(c echo.Context) error {
+	return c.HTML(http.StatusOK, docsPage)
+}
+
+// GetDocsAsset serves the scripts, styles and icons of Swagger UI.
+func (h *DocsHandler) GetDocsAsset(c echo.Context) error {
+	return echo.StaticFileHandler(c.Param("file"), swaggerFiles.FS)(c)
+}
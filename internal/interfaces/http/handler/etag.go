@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"task-be/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// taskETag is the entity tag of a task, its version. Fields derived from
+// other resources, such as comment_count or blocked, do not change it.
+func taskETag(task *domain.Task) string {
+	return `"` + strconv.FormatUint(uint64(task.Version), 10) + `"`
+}
+
+// respondWithTask writes a single task along with its ETag.
+func respondWithTask(c echo.Context, status int, task *domain.Task) error {
+	c.Response().Header().Set("ETag", taskETag(task))
+	return c.JSON(status, toTaskResponse(task))
+}
+
+// parseIfMatch returns the task version required by the If-Match header,
+// or nil when the request is unconditional. Only a single tag is accepted;
+// weak or foreign tags can never match and fail with 412.
+func parseIfMatch(c echo.Context) (*uint, error) {
+	header := strings.TrimSpace(c.Request().Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+	if strings.Contains(header, ",") {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "If-Match must hold a single entity tag")
+	}
+	if strings.HasPrefix(header, "W/") {
+		return nil, echo.NewHTTPError(http.StatusPreconditionFailed, "Task version does not match If-Match")
+	}
+	if len(header) < 2 || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid If-Match header, expected a quoted entity tag")
+	}
+
+	version, err := strconv.ParseUint(header[1:len(header)-1], 10, 32)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusPreconditionFailed, "Task version does not match If-Match")
+	}
+	v := uint(version)
+	return &v, nil
+}
+
+// versionMismatchError answers a write that lost against another one: 412
+// when the client's If-Match version is stale, 409 when the task changed
+// between reading and writing it within the request.
+func versionMismatchError(version *uint) error {
+	if version != nil {
+		return echo.NewHTTPError(http.StatusPreconditionFailed, "Task version does not match If-Match")
+	}
+	return echo.NewHTTPError(http.StatusConflict, "Task was modified concurrently, retry the request")
+}
+
+// notModified reports whether the If-None-Match header lists etag, using
+// the weak comparison RFC 9110 prescribes for it.
+func notModified(c echo.Context, etag string) bool {
+	header := c.Request().Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
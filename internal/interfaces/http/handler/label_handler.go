@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"task-be/internal/domain"
+	"task-be/internal/interfaces/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type LabelHandler struct {
+	labelService domain.LabelService
+	authorizer   domain.Authorizer
+}
+
+func NewLabelHandler(labelService domain.LabelService, authorizer domain.Authorizer) *LabelHandler {
+	return &LabelHandler{labelService: labelService, authorizer: authorizer}
+}
+
+func (h *LabelHandler) CreateLabel(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionLabelManage); err != nil {
+		return err
+	}
+
+	var req dto.CreateLabelRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	label, err := h.labelService.CreateLabel(c.Request().Context(), domain.LabelInput{
+		Name:  req.Name,
+		Color: req.Color,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, toLabelResponse(label))
+}
+
+func (h *LabelHandler) GetLabels(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskRead); err != nil {
+		return err
+	}
+
+	labels, err := h.labelService.GetLabels(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.LabelListResponse{Labels: toLabelResponses(labels)})
+}
+
+func (h *LabelHandler) GetLabelByID(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskRead); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid label ID")
+	}
+
+	label, err := h.labelService.GetLabelByID(c.Request().Context(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, toLabelResponse(label))
+}
+
+func (h *LabelHandler) UpdateLabel(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionLabelManage); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid label ID")
+	}
+
+	var req dto.UpdateLabelRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	label, err := h.labelService.UpdateLabel(c.Request().Context(), uint(id), domain.UpdateLabelInput{
+		Name:  req.Name,
+		Color: req.Color,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, toLabelResponse(label))
+}
+
+func (h *LabelHandler) DeleteLabel(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionLabelManage); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid label ID")
+	}
+
+	err = h.labelService.DeleteLabel(c.Request().Context(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func toLabelResponse(label *domain.Label) dto.LabelResponse {
+	return dto.LabelResponse{
+		ID:        label.ID,
+		Name:      label.Name,
+		Color:     label.Color,
+		CreatedAt: label.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: label.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toLabelResponses(labels []domain.Label) []dto.LabelResponse {
+	responses := make([]dto.LabelResponse, 0, len(labels))
+	for i := range labels {
+		responses = append(responses, toLabelResponse(&labels[i]))
+	}
+	return responses
+}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"task-be/internal/domain"
+	"task-be/internal/infrastructure/logger"
+	"task-be/internal/interfaces/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	mimeApplicationProblemJSON = "application/problem+json"
+	// problemTypeBase prefixes the type URI of every problem. Types are
+	// stable, so clients can tell problems apart without parsing detail.
+	problemTypeBase = "urn:task-be:problem:"
+)
+
+// problemKinds maps each kind of domain error to its status and problem
+// type.
+var problemKinds = []struct {
+	kind   error
+	status int
+	name   string
+}{
+	{domain.ErrValidation, http.StatusBadRequest, "validation-error"},
+	{domain.ErrUnauthenticated, http.StatusUnauthorized, "unauthenticated"},
+	{domain.ErrForbidden, http.StatusForbidden, "forbidden"},
+	{domain.ErrNotFound, http.StatusNotFound, "not-found"},
+	{domain.ErrInvalidTransition, http.StatusConflict, "invalid-transition"},
+	{domain.ErrConflict, http.StatusConflict, "conflict"},
+	{domain.ErrUnprocessable, http.StatusUnprocessableEntity, "unprocessable"},
+}
+
+// statusProblemTypes names the problem types of errors raised with a status
+// by handlers and middleware. Other statuses get about:blank.
+var statusProblemTypes = map[int]string{
+	http.StatusBadRequest:            "bad-request",
+	http.StatusUnauthorized:          "unauthenticated",
+	http.StatusForbidden:             "forbidden",
+	http.StatusNotFound:              "not-found",
+	http.StatusMethodNotAllowed:      "method-not-allowed",
+	http.StatusConflict:              "conflict",
+	http.StatusPreconditionFailed:    "precondition-failed",
+	http.StatusRequestEntityTooLarge: "payload-too-large",
+	http.StatusUnsupportedMediaType:  "unsupported-media-type",
+	http.StatusUnprocessableEntity:   "unprocessable",
+	http.StatusInternalServerError:   "internal-error",
+}
+
+// HTTPErrorHandler renders every error returned by a handler or middleware
+// as an application/problem+json document. Domain errors get the status of
+// their kind. The cause of a 5xx is logged along with the request ID and
+// never sent to the client.
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	req := c.Request()
+	status, name := problemStatus(err)
+	problem := dto.ProblemResponse{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Instance:  req.URL.Path,
+		RequestID: c.Response().Header().Get(echo.HeaderXRequestID),
+	}
+	if name != "" {
+		problem.Type = problemTypeBase + name
+	}
+
+	if status >= http.StatusInternalServerError {
+		log := logger.GetLogger()
+		log.Error("Request failed", "error", err, "method", req.Method, "path", req.URL.Path, "request_id", problem.RequestID)
+	} else {
+		problem.Detail = problemDetail(err)
+	}
+
+	var body any = problem
+	var domainErr *domain.Error
+	var transitionErr *domain.TransitionError
+	if errors.As(err, &transitionErr) {
+		body = dto.TransitionProblemResponse{
+			ProblemResponse:    problem,
+			CurrentStatus:      string(transitionErr.From),
+			RequestedStatus:    string(transitionErr.To),
+			AllowedTransitions: toStatusStrings(transitionErr.Allowed),
+		}
+	} else if errors.As(err, &domainErr) && status < http.StatusInternalServerError {
+		for _, field := range domainErr.Fields {
+			problem.Errors = append(problem.Errors, dto.FieldErrorResponse{Field: field.Field, Code: field.Code, Message: field.Message})
+		}
+		body = problem
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, mimeApplicationProblemJSON)
+	if req.Method == http.MethodHead {
+		err = c.NoContent(status)
+	} else {
+		err = c.JSON(status, body)
+	}
+	if err != nil {
+		log := logger.GetLogger()
+		log.Error("Failed to send error response", "error", err, "request_id", problem.RequestID)
+	}
+}
+
+// problemStatus returns the status of an error and the name of its problem
+// type, empty for about:blank. A status chosen by the handler wins over the
+// kind of the error it wraps; errors of no known kind are internal.
+func problemStatus(err error) (int, string) {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code, statusProblemTypes[httpErr.Code]
+	}
+	for _, problemKind := range problemKinds {
+		if errors.Is(err, problemKind.kind) {
+			return problemKind.status, problemKind.name
+		}
+	}
+	return http.StatusInternalServerError, statusProblemTypes[http.StatusInternalServerError]
+}
+
+func problemDetail(err error) string {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return fmt.Sprint(httpErr.Message)
+	}
+	return err.Error()
+}
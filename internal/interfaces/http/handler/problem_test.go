@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"task-be/internal/domain"
+	"task-be/internal/interfaces/http/dto"
+
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+)
+
+// serveError answers a request to a route that fails with err, with the
+// problem handler and request IDs set up as in the router.
+func serveError(t *testing.T, method string, err error) *httptest.ResponseRecorder {
+	t.Helper()
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler
+	e.Use(echoMiddleware.RequestID())
+	e.Add(method, "/tasks/1", func(c echo.Context) error { return err })
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(method, "/tasks/1", nil))
+	return rec
+}
+
+func decodeProblem(t *testing.T, rec *httptest.ResponseRecorder) dto.ProblemResponse {
+	t.Helper()
+	if contentType := rec.Header().Get(echo.HeaderContentType); contentType != mimeApplicationProblemJSON {
+		t.Errorf("Expected content type %s, got %s", mimeApplicationProblemJSON, contentType)
+	}
+	var problem dto.ProblemResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Expected a problem document, got %q (%v)", rec.Body.String(), err)
+	}
+	return problem
+}
+
+func TestHTTPErrorHandlerMapsDomainErrors(t *testing.T) {
+	tests := []struct {
+		err     error
+		status  int
+		problem string
+	}{
+		{domain.NewValidationError("invalid task data"), http.StatusBadRequest, "urn:task-be:problem:validation-error"},
+		{domain.ErrAuthenticationRequired, http.StatusUnauthorized, "urn:task-be:problem:unauthenticated"},
+		{&domain.Error{Kind: domain.ErrForbidden, Message: "permission denied"}, http.StatusForbidden, "urn:task-be:problem:forbidden"},
+		{domain.NewNotFoundError("task not found"), http.StatusNotFound, "urn:task-be:problem:not-found"},
+		{domain.NewConflictError("task is blocked by open dependencies"), http.StatusConflict, "urn:task-be:problem:conflict"},
+		{domain.ErrVersionMismatch, http.StatusConflict, "urn:task-be:problem:conflict"},
+		{domain.NewUnprocessableError("parent task not found"), http.StatusUnprocessableEntity, "urn:task-be:problem:unprocessable"},
+		{fmt.Errorf("loading task: %w", domain.NewNotFoundError("task not found")), http.StatusNotFound, "urn:task-be:problem:not-found"},
+		{echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID"), http.StatusBadRequest, "urn:task-be:problem:bad-request"},
+		{echo.NewHTTPError(http.StatusPreconditionFailed, "If-Match does not match"), http.StatusPreconditionFailed, "urn:task-be:problem:precondition-failed"},
+		{echo.NewHTTPError(http.StatusTeapot, "No coffee"), http.StatusTeapot, "about:blank"},
+	}
+	for _, tt := range tests {
+		rec := serveError(t, http.MethodGet, tt.err)
+		problem := decodeProblem(t, rec)
+
+		if rec.Code != tt.status || problem.Status != tt.status {
+			t.Errorf("Expected status %d for %v, got %d (body %d)", tt.status, tt.err, rec.Code, problem.Status)
+		}
+		if problem.Type != tt.problem {
+			t.Errorf("Expected type %s for %v, got %s", tt.problem, tt.err, problem.Type)
+		}
+		if problem.Title != http.StatusText(tt.status) {
+			t.Errorf("Expected title %q, got %q", http.StatusText(tt.status), problem.Title)
+		}
+		if problem.Detail == "" || problem.Instance != "/tasks/1" {
+			t.Errorf("Expected detail and instance for %v, got %+v", tt.err, problem)
+		}
+	}
+}
+
+func TestHTTPErrorHandlerReportsFieldErrors(t *testing.T) {
+	rec := serveError(t, http.MethodPost, domain.NewValidationError("invalid task data",
+		domain.FieldError{Field: "title", Code: "required", Message: "is required"}))
+	problem := decodeProblem(t, rec)
+
+	if problem.Detail != "invalid task data" {
+		t.Errorf("Expected detail invalid task data, got %q", problem.Detail)
+	}
+	if len(problem.Errors) != 1 || problem.Errors[0] != (dto.FieldErrorResponse{Field: "title", Code: "required", Message: "is required"}) {
+		t.Errorf("Expected the title field error, got %+v", problem.Errors)
+	}
+}
+
+func TestHTTPErrorHandlerHidesInternalErrors(t *testing.T) {
+	rec := serveError(t, http.MethodGet, errors.New(`pq: relation "tasks" does not exist`))
+	problem := decodeProblem(t, rec)
+
+	if rec.Code != http.StatusInternalServerError || problem.Type != "urn:task-be:problem:internal-error" {
+		t.Errorf("Expected internal error, got %d %s", rec.Code, problem.Type)
+	}
+	if problem.Detail != "" || strings.Contains(rec.Body.String(), "relation") {
+		t.Errorf("Expected the cause to stay out of the response, got %s", rec.Body.String())
+	}
+
+	requestID := rec.Header().Get(echo.HeaderXRequestID)
+	if requestID == "" || problem.RequestID != requestID {
+		t.Errorf("Expected request ID %q in the problem, got %q", requestID, problem.RequestID)
+	}
+
+	// A status the handler chose is kept, but its message is hidden too.
+	rec = serveError(t, http.MethodGet, echo.NewHTTPError(http.StatusServiceUnavailable, "database is down"))
+	if problem := decodeProblem(t, rec); rec.Code != http.StatusServiceUnavailable || problem.Detail != "" {
+		t.Errorf("Expected 503 without detail, got %d %q", rec.Code, problem.Detail)
+	}
+}
+
+func TestHTTPErrorHandlerDescribesTransitionErrors(t *testing.T) {
+	err := &domain.TransitionError{From: domain.StatusToDo, To: domain.StatusDone, Allowed: []domain.TaskStatus{domain.StatusInProgress}}
+	rec := serveError(t, http.MethodPatch, err)
+
+	var problem dto.TransitionProblemResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("Expected a problem document, got %q (%v)", rec.Body.String(), err)
+	}
+	if rec.Code != http.StatusConflict || problem.Type != "urn:task-be:problem:invalid-transition" {
+		t.Errorf("Expected invalid transition conflict, got %d %s", rec.Code, problem.Type)
+	}
+	if problem.CurrentStatus != "TO_DO" || problem.RequestedStatus != "DONE" {
+		t.Errorf("Expected transition from TO_DO to DONE, got %s to %s", problem.CurrentStatus, problem.RequestedStatus)
+	}
+	if len(problem.AllowedTransitions) != 1 || problem.AllowedTransitions[0] != "IN_PROGRESS" {
+		t.Errorf("Expected allowed transitions [IN_PROGRESS], got %v", problem.AllowedTransitions)
+	}
+	if problem.RequestID == "" || problem.Detail != err.Error() {
+		t.Errorf("Expected request ID and detail, got %+v", problem.ProblemResponse)
+	}
+}
+
+func TestHTTPErrorHandlerSendsNoBodyForHead(t *testing.T) {
+	rec := serveError(t, http.MethodHead, domain.NewNotFoundError("task not found"))
+
+	if rec.Code != http.StatusNotFound || rec.Body.Len() != 0 {
+		t.Errorf("Expected 404 without body, got %d %q", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"task-be/internal/domain"
+	"task-be/internal/interfaces/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ProjectHandler struct {
+	projectService domain.ProjectService
+	taskService    domain.TaskService
+	authorizer     domain.Authorizer
+}
+
+func NewProjectHandler(projectService domain.ProjectService, taskService domain.TaskService, authorizer domain.Authorizer) *ProjectHandler {
+	return &ProjectHandler{projectService: projectService, taskService: taskService, authorizer: authorizer}
+}
+
+func (h *ProjectHandler) CreateProject(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionProjectManage); err != nil {
+		return err
+	}
+
+	var req dto.CreateProjectRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	project, err := h.projectService.CreateProject(c.Request().Context(), domain.ProjectInput{
+		Key:         req.Key,
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, toProjectResponse(project))
+}
+
+func (h *ProjectHandler) GetProjects(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskRead); err != nil {
+		return err
+	}
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+	includeArchived := false
+	if archived := c.QueryParam("archived"); archived != "" {
+		var err error
+		includeArchived, err = strconv.ParseBool(archived)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid archived, expected true or false")
+		}
+	}
+
+	projects, total, err := h.projectService.GetProjects(c.Request().Context(), page, limit, includeArchived)
+	if err != nil {
+		return err
+	}
+
+	projectResponses := make([]dto.ProjectResponse, 0, len(projects))
+	for i := range projects {
+		projectResponses = append(projectResponses, toProjectResponse(&projects[i]))
+	}
+
+	response := dto.ProjectListResponse{
+		Projects: projectResponses,
+		Total:    total,
+		Page:     page,
+		Limit:    limit,
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *ProjectHandler) GetProjectByID(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskRead); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid project ID")
+	}
+
+	project, err := h.projectService.GetProjectByID(c.Request().Context(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, toProjectResponse(project))
+}
+
+func (h *ProjectHandler) UpdateProject(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionProjectManage); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid project ID")
+	}
+
+	var req dto.UpdateProjectRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	project, err := h.projectService.UpdateProject(c.Request().Context(), uint(id), domain.UpdateProjectInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Archived:    req.Archived,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, toProjectResponse(project))
+}
+
+func (h *ProjectHandler) DeleteProject(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionProjectManage); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid project ID")
+	}
+
+	err = h.projectService.DeleteProject(c.Request().Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return echo.NewHTTPError(http.StatusConflict, "Project still has tasks, archive it instead")
+		}
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *ProjectHandler) GetProjectTasks(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskRead); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid project ID")
+	}
+
+	if _, err := h.projectService.GetProjectByID(c.Request().Context(), uint(id)); err != nil {
+		return err
+	}
+
+	filter, err := parseTaskFilter(c, listQueryParams...)
+	if err != nil {
+		return err
+	}
+	if filter.Deleted {
+		if err := authorize(c, h.authorizer, domain.PermissionTaskDelete); err != nil {
+			return err
+		}
+	}
+	projectID := uint(id)
+	filter.ProjectID = &projectID
+
+	return listTasks(c, h.taskService, filter)
+}
+
+func toProjectResponse(project *domain.Project) dto.ProjectResponse {
+	return dto.ProjectResponse{
+		ID:          project.ID,
+		Key:         project.Key,
+		Name:        project.Name,
+		Description: project.Description,
+		Archived:    project.Archived,
+		CreatedAt:   project.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   project.UpdatedAt.Format(time.RFC3339),
+	}
+}
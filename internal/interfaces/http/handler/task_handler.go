@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"task-be/internal/domain"
@@ -13,139 +16,452 @@ import (
 
 type TaskHandler struct {
 	taskService domain.TaskService
+	authorizer  domain.Authorizer
 }
 
-func NewTaskHandler(taskService domain.TaskService) *TaskHandler {
-	return &TaskHandler{taskService: taskService}
+func NewTaskHandler(taskService domain.TaskService, authorizer domain.Authorizer) *TaskHandler {
+	return &TaskHandler{taskService: taskService, authorizer: authorizer}
 }
 
 func (h *TaskHandler) CreateTask(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskCreate); err != nil {
+		return err
+	}
+
 	var req dto.CreateTaskRequest
 	if err := c.Bind(&req); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
 	}
 
 	if err := c.Validate(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
-	task, err := h.taskService.CreateTask(c.Request().Context(), req.Title, req.Description)
+	input, err := toCreateTaskInput(req)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
-	response := dto.TaskResponse{
-		ID:          task.ID,
-		Title:       task.Title,
-		Description: task.Description,
-		Status:      string(task.Status),
-		CreatedAt:   task.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   task.UpdatedAt.Format(time.RFC3339),
+	task, err := h.taskService.CreateTask(c.Request().Context(), input)
+	if err != nil {
+		return err
 	}
 
-	return c.JSON(http.StatusCreated, response)
+	return respondWithTask(c, http.StatusCreated, task)
 }
 
 func (h *TaskHandler) GetTasks(c echo.Context) error {
-	page, _ := strconv.Atoi(c.QueryParam("page"))
-	limit, _ := strconv.Atoi(c.QueryParam("limit"))
-	status := c.QueryParam("status")
+	if err := authorize(c, h.authorizer, domain.PermissionTaskRead); err != nil {
+		return err
+	}
 
-	var taskStatus *domain.TaskStatus
-	if status != "" {
-		ts := domain.TaskStatus(status)
-		taskStatus = &ts
+	filter, err := parseTaskFilter(c, listQueryParams...)
+	if err != nil {
+		return err
+	}
+	if filter.Deleted {
+		if err := authorize(c, h.authorizer, domain.PermissionTaskDelete); err != nil {
+			return err
+		}
+	}
+
+	return listTasks(c, h.taskService, filter)
+}
+
+// SearchTasks runs a full-text query over titles and descriptions. The
+// filters of GET /tasks apply on top of the query.
+func (h *TaskHandler) SearchTasks(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskRead); err != nil {
+		return err
 	}
 
-	tasks, total, err := h.taskService.GetTasks(c.Request().Context(), page, limit, taskStatus)
+	filter, err := parseTaskFilter(c, "q")
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
+	}
+	if filter.Sort != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Search results are ordered by relevance and cannot be sorted")
 	}
+	if filter.Deleted {
+		if err := authorize(c, h.authorizer, domain.PermissionTaskDelete); err != nil {
+			return err
+		}
+	}
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
 
-	var taskResponses []dto.TaskResponse
-	for _, task := range tasks {
-		taskResponses = append(taskResponses, dto.TaskResponse{
-			ID:          task.ID,
-			Title:       task.Title,
-			Description: task.Description,
-			Status:      string(task.Status),
-			CreatedAt:   task.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:   task.UpdatedAt.Format(time.RFC3339),
+	results, total, err := h.taskService.SearchTasks(c.Request().Context(), c.QueryParam("q"), page, limit, filter)
+	if err != nil {
+		return err
+	}
+
+	resultResponses := make([]dto.TaskSearchResultResponse, 0, len(results))
+	for i := range results {
+		resultResponses = append(resultResponses, dto.TaskSearchResultResponse{
+			TaskResponse: toTaskResponse(&results[i].Task),
+			Rank:         results[i].Rank,
+			Highlight: dto.TaskHighlightResponse{
+				Title:       results[i].TitleHighlight,
+				Description: results[i].DescriptionHighlight,
+			},
 		})
 	}
 
-	response := dto.TaskListResponse{
-		Tasks: taskResponses,
-		Total: total,
-		Page:  page,
-		Limit: limit,
+	response := dto.TaskSearchResponse{
+		Results: resultResponses,
+		Total:   total,
+		Page:    page,
+		Limit:   limit,
 	}
 
 	return c.JSON(http.StatusOK, response)
 }
 
+// GetTaskByID resolves either a numeric ID or a project task key such as
+// OPS-42.
 func (h *TaskHandler) GetTaskByID(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskRead); err != nil {
+		return err
+	}
+
+	var task *domain.Task
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err == nil {
+		task, err = h.taskService.GetTaskByID(c.Request().Context(), uint(id))
+	} else if key, ok := domain.ParseTaskKey(c.Param("id")); ok {
+		task, err = h.taskService.GetTaskByKey(c.Request().Context(), key)
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID or key")
+	}
+	if err != nil {
+		return err
+	}
+
+	if notModified(c, taskETag(task)) {
+		c.Response().Header().Set("ETag", taskETag(task))
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return respondWithTask(c, http.StatusOK, task)
+}
+
+// UpdateTask applies a partial update. With an If-Match header the update
+// only succeeds while the task is still at that version.
+func (h *TaskHandler) UpdateTask(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskUpdate); err != nil {
+		return err
+	}
+
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
 	}
 
-	task, err := h.taskService.GetTaskByID(c.Request().Context(), uint(id))
+	version, err := parseIfMatch(c)
 	if err != nil {
-		if err.Error() == "task not found" {
-			return echo.NewHTTPError(http.StatusNotFound, "Task not found")
+		return err
+	}
+
+	var req dto.UpdateTaskRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	input, err := toUpdateTaskInput(req)
+	if err != nil {
+		return err
+	}
+	input.Version = version
+	if input.Force, err = parseForce(c); err != nil {
+		return err
+	}
+
+	task, err := h.taskService.UpdateTask(c.Request().Context(), uint(id), input)
+	if err != nil {
+		if errors.Is(err, domain.ErrVersionMismatch) {
+			return versionMismatchError(version)
 		}
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
-	response := dto.TaskResponse{
-		ID:          task.ID,
-		Title:       task.Title,
-		Description: task.Description,
-		Status:      string(task.Status),
-		CreatedAt:   task.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   task.UpdatedAt.Format(time.RFC3339),
+	return respondWithTask(c, http.StatusOK, task)
+}
+
+func (h *TaskHandler) GetTaskChildren(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskRead); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
+	}
+
+	if _, err := h.taskService.GetTaskByID(c.Request().Context(), uint(id)); err != nil {
+		return err
+	}
+
+	parentID := uint(id)
+	return listTasks(c, h.taskService, domain.TaskFilter{ParentID: &parentID})
+}
+
+func (h *TaskHandler) GetTaskDependencies(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskRead); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
+	}
+
+	dependencies, err := h.taskService.GetTaskDependencies(c.Request().Context(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	response := dto.TaskDependenciesResponse{
+		TaskID:    uint(id),
+		BlockedBy: make([]dto.TaskResponse, 0, len(dependencies.BlockedBy)),
+		Blocks:    make([]dto.TaskResponse, 0, len(dependencies.Blocks)),
+	}
+	for i := range dependencies.BlockedBy {
+		response.BlockedBy = append(response.BlockedBy, toTaskResponse(&dependencies.BlockedBy[i]))
+	}
+	for i := range dependencies.Blocks {
+		response.Blocks = append(response.Blocks, toTaskResponse(&dependencies.Blocks[i]))
 	}
 
 	return c.JSON(http.StatusOK, response)
 }
 
-func (h *TaskHandler) UpdateTask(c echo.Context) error {
+func (h *TaskHandler) AddTaskDependency(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskUpdate); err != nil {
+		return err
+	}
+
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
 	}
 
-	var req dto.UpdateTaskRequest
+	var req dto.AddTaskDependencyRequest
 	if err := c.Bind(&req); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
 	}
 
 	if err := c.Validate(&req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
-	var taskStatus *domain.TaskStatus
-	if req.Status != nil {
-		ts := domain.TaskStatus(*req.Status)
-		taskStatus = &ts
+	task, err := h.taskService.AddTaskDependency(c.Request().Context(), uint(id), req.BlockedByID)
+	if err != nil {
+		return err
+	}
+
+	return respondWithTask(c, http.StatusOK, task)
+}
+
+func (h *TaskHandler) RemoveTaskDependency(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskUpdate); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
+	}
+
+	blockerID, err := strconv.ParseUint(c.Param("blockerId"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid blocking task ID")
 	}
 
-	task, err := h.taskService.UpdateTask(c.Request().Context(), uint(id), req.Title, req.Description, taskStatus)
+	task, err := h.taskService.RemoveTaskDependency(c.Request().Context(), uint(id), uint(blockerID))
 	if err != nil {
-		if err.Error() == "task not found" {
-			return echo.NewHTTPError(http.StatusNotFound, "Task not found")
+		return err
+	}
+
+	return respondWithTask(c, http.StatusOK, task)
+}
+
+func (h *TaskHandler) AssignTask(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskUpdate); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
+	}
+
+	var req dto.AssignTaskRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	task, err := h.taskService.AssignTask(c.Request().Context(), uint(id), req.AssigneeID)
+	if err != nil {
+		if errors.Is(err, domain.ErrVersionMismatch) {
+			return versionMismatchError(nil)
 		}
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
-	response := dto.TaskResponse{
-		ID:          task.ID,
-		Title:       task.Title,
-		Description: task.Description,
-		Status:      string(task.Status),
-		CreatedAt:   task.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   task.UpdatedAt.Format(time.RFC3339),
+	return respondWithTask(c, http.StatusOK, task)
+}
+
+func (h *TaskHandler) UnassignTask(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskUpdate); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
+	}
+
+	task, err := h.taskService.UnassignTask(c.Request().Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrVersionMismatch) {
+			return versionMismatchError(nil)
+		}
+		return err
+	}
+
+	return respondWithTask(c, http.StatusOK, task)
+}
+
+func (h *TaskHandler) AddTaskLabel(c echo.Context) error {
+	return h.changeTaskLabel(c, true)
+}
+
+func (h *TaskHandler) RemoveTaskLabel(c echo.Context) error {
+	return h.changeTaskLabel(c, false)
+}
+
+func (h *TaskHandler) changeTaskLabel(c echo.Context, attach bool) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskUpdate); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
+	}
+
+	labelID, err := strconv.ParseUint(c.Param("labelId"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid label ID")
+	}
+
+	var task *domain.Task
+	if attach {
+		task, err = h.taskService.AddTaskLabel(c.Request().Context(), uint(id), uint(labelID))
+	} else {
+		task, err = h.taskService.RemoveTaskLabel(c.Request().Context(), uint(id), uint(labelID))
+	}
+	if err != nil {
+		return err
+	}
+
+	return respondWithTask(c, http.StatusOK, task)
+}
+
+func (h *TaskHandler) RestoreTask(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskDelete); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
+	}
+
+	task, err := h.taskService.RestoreTask(c.Request().Context(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return respondWithTask(c, http.StatusOK, task)
+}
+
+func (h *TaskHandler) GetTaskTransitions(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskRead); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
+	}
+
+	task, allowed, err := h.taskService.GetAllowedTransitions(c.Request().Context(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	response := dto.TaskTransitionsResponse{
+		TaskID:             task.ID,
+		CurrentStatus:      string(task.Status),
+		AllowedTransitions: toStatusStrings(allowed),
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *TaskHandler) GetTaskHistory(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionTaskHistoryRead); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
+	}
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+	events, total, err := h.taskService.GetTaskHistory(c.Request().Context(), uint(id), page, limit)
+	if err != nil {
+		return err
+	}
+
+	eventResponses := make([]dto.TaskEventResponse, 0, len(events))
+	for _, event := range events {
+		changes := make([]dto.FieldChangeResponse, 0, len(event.Changes))
+		for _, change := range event.Changes {
+			changes = append(changes, dto.FieldChangeResponse{
+				Field:  change.Field,
+				Before: change.Before,
+				After:  change.After,
+			})
+		}
+		eventResponses = append(eventResponses, dto.TaskEventResponse{
+			ID:        event.ID,
+			TaskID:    event.TaskID,
+			Type:      string(event.Type),
+			Actor:     event.Actor,
+			Changes:   changes,
+			CreatedAt: event.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	response := dto.TaskHistoryResponse{
+		Events: eventResponses,
+		Total:  total,
+		Page:   page,
+		Limit:  limit,
 	}
 
 	return c.JSON(http.StatusOK, response)
@@ -157,13 +473,434 @@ func (h *TaskHandler) DeleteTask(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid task ID")
 	}
 
-	err = h.taskService.DeleteTask(c.Request().Context(), uint(id))
+	permanent := false
+	if p := c.QueryParam("permanent"); p != "" {
+		permanent, err = strconv.ParseBool(p)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid permanent, expected true or false")
+		}
+	}
+
+	permission := domain.PermissionTaskDelete
+	if permanent {
+		permission = domain.PermissionTaskPurge
+	}
+	if err := authorize(c, h.authorizer, permission); err != nil {
+		return err
+	}
+
+	version, err := parseIfMatch(c)
+	if err != nil {
+		return err
+	}
+
+	if permanent {
+		err = h.taskService.PurgeTask(c.Request().Context(), uint(id), version)
+	} else {
+		err = h.taskService.DeleteTask(c.Request().Context(), uint(id), version)
+	}
 	if err != nil {
-		if err.Error() == "task not found" {
-			return echo.NewHTTPError(http.StatusNotFound, "Task not found")
+		if errors.Is(err, domain.ErrVersionMismatch) {
+			return versionMismatchError(version)
 		}
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+// taskQueryParams are the query parameters task list endpoints accept. Any
+// other parameter is rejected instead of being silently ignored, unless the
+// endpoint names it as one of its own.
+var taskQueryParams = map[string]bool{
+	"status": true, "priority": true, "due_before": true, "due_after": true,
+	"created_before": true, "created_after": true, "updated_before": true, "updated_after": true,
+	"title_contains": true, "overdue": true, "deleted": true, "assignee": true, "created_by": true,
+	"project_id": true, "parent_id": true, "labels": true, "label_mode": true, "sort": true,
+	"page": true, "limit": true,
+}
+
+// listQueryParams select cursor pagination, which only the endpoints that
+// respond through listTasks offer.
+var listQueryParams = []string{"paginate", "cursor", "include_total"}
+
+// parseTaskFilter reads the listing filters shared by every task list
+// endpoint from the query string. own lists the parameters the endpoint
+// reads itself on top of taskQueryParams.
+func parseTaskFilter(c echo.Context, own ...string) (domain.TaskFilter, error) {
+	var filter domain.TaskFilter
+	for name := range c.QueryParams() {
+		if !taskQueryParams[name] && !slices.Contains(own, name) {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "Unknown query parameter "+name)
+		}
+	}
+
+	status := c.QueryParam("status")
+	priority := c.QueryParam("priority")
+
+	if status != "" {
+		var task domain.Task
+		for _, name := range strings.Split(status, ",") {
+			ts := domain.TaskStatus(strings.TrimSpace(name))
+			if !task.IsValidStatus(ts) {
+				return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid status, expected TO_DO, IN_PROGRESS or DONE")
+			}
+			filter.Statuses = append(filter.Statuses, ts)
+		}
+	}
+	if priority != "" {
+		var task domain.Task
+		tp := domain.TaskPriority(priority)
+		if !task.IsValidPriority(tp) {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid priority, expected LOW, MEDIUM, HIGH or URGENT")
+		}
+		filter.Priority = &tp
+	}
+	if dueBefore := c.QueryParam("due_before"); dueBefore != "" {
+		t, err := time.Parse(time.RFC3339, dueBefore)
+		if err != nil {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid due_before, expected RFC3339 timestamp")
+		}
+		filter.DueBefore = &t
+	}
+	if dueAfter := c.QueryParam("due_after"); dueAfter != "" {
+		t, err := time.Parse(time.RFC3339, dueAfter)
+		if err != nil {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid due_after, expected RFC3339 timestamp")
+		}
+		filter.DueAfter = &t
+	}
+	if createdBefore := c.QueryParam("created_before"); createdBefore != "" {
+		t, err := time.Parse(time.RFC3339, createdBefore)
+		if err != nil {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid created_before, expected RFC3339 timestamp")
+		}
+		filter.CreatedBefore = &t
+	}
+	if createdAfter := c.QueryParam("created_after"); createdAfter != "" {
+		t, err := time.Parse(time.RFC3339, createdAfter)
+		if err != nil {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid created_after, expected RFC3339 timestamp")
+		}
+		filter.CreatedAfter = &t
+	}
+	if updatedBefore := c.QueryParam("updated_before"); updatedBefore != "" {
+		t, err := time.Parse(time.RFC3339, updatedBefore)
+		if err != nil {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid updated_before, expected RFC3339 timestamp")
+		}
+		filter.UpdatedBefore = &t
+	}
+	if updatedAfter := c.QueryParam("updated_after"); updatedAfter != "" {
+		t, err := time.Parse(time.RFC3339, updatedAfter)
+		if err != nil {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid updated_after, expected RFC3339 timestamp")
+		}
+		filter.UpdatedAfter = &t
+	}
+	filter.TitleContains = strings.TrimSpace(c.QueryParam("title_contains"))
+	if deleted := c.QueryParam("deleted"); deleted != "" {
+		d, err := strconv.ParseBool(deleted)
+		if err != nil {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid deleted, expected true or false")
+		}
+		filter.Deleted = d
+	}
+	if overdue := c.QueryParam("overdue"); overdue != "" {
+		o, err := strconv.ParseBool(overdue)
+		if err != nil {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid overdue, expected true or false")
+		}
+		filter.Overdue = &o
+	}
+	if assignee := c.QueryParam("assignee"); assignee != "" {
+		if assignee == "none" {
+			filter.Unassigned = true
+		} else {
+			userID, err := parseUserParam(c, "assignee", assignee)
+			if err != nil {
+				return filter, err
+			}
+			filter.AssigneeID = &userID
+		}
+	}
+	if createdBy := c.QueryParam("created_by"); createdBy != "" {
+		userID, err := parseUserParam(c, "created_by", createdBy)
+		if err != nil {
+			return filter, err
+		}
+		filter.CreatedBy = &userID
+	}
+	if projectID := c.QueryParam("project_id"); projectID != "" {
+		id, err := strconv.ParseUint(projectID, 10, 32)
+		if err != nil {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid project_id, expected a project ID")
+		}
+		pid := uint(id)
+		filter.ProjectID = &pid
+	}
+	if parentID := c.QueryParam("parent_id"); parentID != "" {
+		if parentID == "none" {
+			filter.TopLevel = true
+		} else {
+			id, err := strconv.ParseUint(parentID, 10, 32)
+			if err != nil {
+				return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid parent_id, expected a task ID or none")
+			}
+			pid := uint(id)
+			filter.ParentID = &pid
+		}
+	}
+	if labels := c.QueryParam("labels"); labels != "" {
+		filter.Labels = strings.Split(labels, ",")
+		filter.LabelMode = domain.LabelMatchMode(c.QueryParam("label_mode"))
+		if filter.LabelMode != "" && !domain.IsValidLabelMatchMode(filter.LabelMode) {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid label_mode, expected any or all")
+		}
+	}
+	if sort := c.QueryParam("sort"); sort != "" {
+		parsed, err := domain.ParseTaskSort(sort)
+		if err != nil {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid sort, "+err.Error())
+		}
+		filter.Sort = parsed
+	}
+
+	return filter, nil
+}
+
+// listTasks responds with a page of the tasks matching filter. Listings are
+// paginated by page number unless a cursor is given or paginate=cursor asks
+// for the first page of cursor pagination.
+func listTasks(c echo.Context, taskService domain.TaskService, filter domain.TaskFilter) error {
+	paginate := c.QueryParam("paginate")
+	if paginate != "" && paginate != "page" && paginate != "cursor" {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid paginate, expected page or cursor")
+	}
+	if paginate != "cursor" && c.QueryParam("cursor") == "" {
+		return listTasksByPage(c, taskService, filter)
+	}
+
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	request := domain.TaskPageRequest{Limit: limit}
+	if cursor := c.QueryParam("cursor"); cursor != "" {
+		decoded, err := domain.DecodeTaskCursor(cursor)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor")
+		}
+		request.Cursor = decoded
+	}
+	if includeTotal := c.QueryParam("include_total"); includeTotal != "" {
+		include, err := strconv.ParseBool(includeTotal)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid include_total, expected true or false")
+		}
+		request.IncludeTotal = include
+	}
+
+	page, err := taskService.GetTaskPage(c.Request().Context(), filter, request)
+	if err != nil {
+		return err
+	}
+
+	taskResponses := make([]dto.TaskResponse, 0, len(page.Tasks))
+	for i := range page.Tasks {
+		taskResponses = append(taskResponses, toTaskResponse(&page.Tasks[i]))
+	}
+
+	response := dto.TaskListResponse{
+		Tasks: taskResponses,
+		Total: page.Total,
+		Limit: page.Limit,
+	}
+	if page.Next != nil {
+		next := page.Next.Encode()
+		response.NextCursor = &next
+	}
+	if page.Prev != nil {
+		prev := page.Prev.Encode()
+		response.PrevCursor = &prev
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func listTasksByPage(c echo.Context, taskService domain.TaskService, filter domain.TaskFilter) error {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	// Report the page that is returned, with the defaults of GetTasks.
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+
+	tasks, total, err := taskService.GetTasks(c.Request().Context(), page, limit, filter)
+	if err != nil {
+		return err
+	}
+
+	taskResponses := make([]dto.TaskResponse, 0, len(tasks))
+	for i := range tasks {
+		taskResponses = append(taskResponses, toTaskResponse(&tasks[i]))
+	}
+
+	response := dto.TaskListResponse{
+		Tasks: taskResponses,
+		Total: &total,
+		Page:  page,
+		Limit: limit,
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func toCreateTaskInput(req dto.CreateTaskRequest) (domain.CreateTaskInput, error) {
+	var fields []domain.FieldError
+	input := domain.CreateTaskInput{
+		Title:       req.Title,
+		Description: req.Description,
+		Priority:    domain.TaskPriority(req.Priority),
+		StartAt:     parseTaskDate("start_at", req.StartAt, &fields),
+		DueAt:       parseTaskDate("due_at", req.DueAt, &fields),
+		ParentID:    req.ParentID,
+		ProjectID:   req.ProjectID,
+	}
+	if len(fields) > 0 {
+		return input, domain.NewValidationError("Request validation failed", fields...)
+	}
+	return input, nil
+}
+
+func toUpdateTaskInput(req dto.UpdateTaskRequest) (domain.UpdateTaskInput, error) {
+	input := domain.UpdateTaskInput{
+		Title:       req.Title,
+		Description: req.Description,
+	}
+	if req.ParentID != nil {
+		if *req.ParentID == 0 {
+			input.ClearParent = true
+		} else {
+			input.ParentID = req.ParentID
+		}
+	}
+	if req.Status != nil {
+		ts := domain.TaskStatus(*req.Status)
+		input.Status = &ts
+	}
+	if req.Priority != nil {
+		tp := domain.TaskPriority(*req.Priority)
+		input.Priority = &tp
+	}
+	// Malformed dates are reported like the fields the validator rejects.
+	var fields []domain.FieldError
+	if req.StartAt != nil {
+		input.ClearStartAt = *req.StartAt == ""
+		input.StartAt = parseTaskDate("start_at", req.StartAt, &fields)
+	}
+	if req.DueAt != nil {
+		input.ClearDueAt = *req.DueAt == ""
+		input.DueAt = parseTaskDate("due_at", req.DueAt, &fields)
+	}
+	if len(fields) > 0 {
+		return input, domain.NewValidationError("Request validation failed", fields...)
+	}
+	return input, nil
+}
+
+// parseTaskDate parses an optional RFC3339 date of a task request. Nil and
+// the empty string give no date; a malformed date is added to fields.
+func parseTaskDate(field string, value *string, fields *[]domain.FieldError) *time.Time {
+	if value == nil || *value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		*fields = append(*fields, domain.FieldError{Field: field, Code: "invalid_format", Message: "must be an RFC3339 timestamp"})
+		return nil
+	}
+	return &t
+}
+
+// parseForce reads the force query parameter of status changes.
+func parseForce(c echo.Context) (bool, error) {
+	force := c.QueryParam("force")
+	if force == "" {
+		return false, nil
+	}
+	value, err := strconv.ParseBool(force)
+	if err != nil {
+		return false, echo.NewHTTPError(http.StatusBadRequest, "Invalid force, expected true or false")
+	}
+	return value, nil
+}
+
+func toTaskResponse(task *domain.Task) dto.TaskResponse {
+	response := dto.TaskResponse{
+		ID:           task.ID,
+		Key:          task.Key,
+		ProjectID:    task.ProjectID,
+		Title:        task.Title,
+		Description:  task.Description,
+		Status:       string(task.Status),
+		Priority:     string(task.Priority),
+		StartAt:      formatOptionalTime(task.StartAt),
+		DueAt:        formatOptionalTime(task.DueAt),
+		CreatedBy:    task.CreatedBy,
+		AssigneeID:   task.AssigneeID,
+		ParentID:     task.ParentID,
+		Progress:     toTaskProgressResponse(task.Progress),
+		Labels:       toLabelResponses(task.Labels),
+		IsOverdue:    task.IsOverdue,
+		IsBlocked:    task.IsBlocked,
+		CommentCount: task.CommentCount,
+		Version:      task.Version,
+		CreatedAt:    task.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    task.UpdatedAt.Format(time.RFC3339),
+		DeletedAt:    formatOptionalTime(task.DeletedAt),
+	}
+	return response
+}
+
+func toTaskProgressResponse(progress domain.TaskProgress) dto.TaskProgressResponse {
+	response := dto.TaskProgressResponse{Done: progress.Done, Total: progress.Total}
+	if progress.Total > 0 {
+		response.Percent = int(progress.Done * 100 / progress.Total)
+	}
+	return response
+}
+
+// parseUserParam resolves a user filter value, either a user ID or "me" for
+// the authenticated caller.
+func parseUserParam(c echo.Context, name, value string) (uint, error) {
+	if value == "me" {
+		user, ok := domain.UserFromContext(c.Request().Context())
+		if !ok {
+			return 0, echo.NewHTTPError(http.StatusUnauthorized, name+"=me requires authentication")
+		}
+		return user.ID, nil
+	}
+
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "Invalid "+name+", expected a user ID or me")
+	}
+	return uint(id), nil
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
+
+func toStatusStrings(statuses []domain.TaskStatus) []string {
+	result := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		result = append(result, string(status))
+	}
+	return result
+}
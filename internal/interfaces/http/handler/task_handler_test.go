@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"task-be/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+// allowAll lets every caller do everything.
+type allowAll struct{}
+
+func (allowAll) Can(user *domain.User, permission domain.Permission) bool {
+	return true
+}
+
+func (allowAll) Authorize(ctx context.Context, permission domain.Permission) error {
+	return nil
+}
+
+// serveTaskRequest sends a request to a task route set up as in the router.
+// The requests tested here fail before the task service is reached.
+func serveTaskRequest(method, path, body string) *httptest.ResponseRecorder {
+	h := NewTaskHandler(nil, allowAll{})
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler
+	e.Validator = NewRequestValidator()
+	e.POST("/tasks", h.CreateTask)
+	e.POST("/tasks/bulk", h.BulkTasks)
+	e.GET("/tasks/search", h.SearchTasks)
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func problemFieldCodes(t *testing.T, rec *httptest.ResponseRecorder) map[string]string {
+	t.Helper()
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d (%s)", rec.Code, rec.Body.String())
+	}
+	problem := decodeProblem(t, rec)
+	codes := make(map[string]string, len(problem.Errors))
+	for _, field := range problem.Errors {
+		codes[field.Field] = field.Code
+	}
+	return codes
+}
+
+func TestCreateTaskReportsInvalidDates(t *testing.T) {
+	rec := serveTaskRequest(http.MethodPost, "/tasks", `{"title":"Task","start_at":"tomorrow","due_at":"2023-11-01"}`)
+
+	codes := problemFieldCodes(t, rec)
+	if codes["start_at"] != "invalid_format" || codes["due_at"] != "invalid_format" {
+		t.Errorf("Expected invalid_format for start_at and due_at, got %v", codes)
+	}
+}
+
+func TestBulkTasksReportsInvalidDates(t *testing.T) {
+	tests := []struct {
+		operation string
+		field     string
+	}{
+		{`{"op":"create","task":{"title":"Task","due_at":"next week"}}`, "operations[1].task.due_at"},
+		{`{"op":"update","id":1,"task":{"start_at":"2023-11-01 17:00"}}`, "operations[1].task.start_at"},
+	}
+	for _, tt := range tests {
+		body := `{"operations":[{"op":"create","task":{"title":"Task"}},` + tt.operation + `]}`
+		rec := serveTaskRequest(http.MethodPost, "/tasks/bulk", body)
+
+		if codes := problemFieldCodes(t, rec); codes[tt.field] != "invalid_format" {
+			t.Errorf("Expected invalid_format for %s, got %v", tt.field, codes)
+		}
+	}
+}
+
+func TestSearchTasksRejectsCursorPagination(t *testing.T) {
+	for _, query := range []string{"cursor=abc", "paginate=cursor", "include_total=true"} {
+		rec := serveTaskRequest(http.MethodGet, "/tasks/search?q=report&"+query, "")
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %s, got %d", query, rec.Code)
+		}
+		if problem := decodeProblem(t, rec); !strings.Contains(problem.Detail, "Unknown query parameter") {
+			t.Errorf("Expected an unknown query parameter for %s, got %q", query, problem.Detail)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"task-be/internal/domain"
+	"task-be/internal/interfaces/http/dto"
+
+	"github.com/labstack/echo/v4"
+)
+
+type UserHandler struct {
+	userService domain.UserService
+	authorizer  domain.Authorizer
+}
+
+func NewUserHandler(userService domain.UserService, authorizer domain.Authorizer) *UserHandler {
+	return &UserHandler{userService: userService, authorizer: authorizer}
+}
+
+func (h *UserHandler) CreateUser(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionUserManage); err != nil {
+		return err
+	}
+
+	var req dto.CreateUserRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	user, err := h.userService.CreateUser(c.Request().Context(), domain.CreateUserInput{
+		Username: req.Username,
+		Password: req.Password,
+		Role:     domain.Role(req.Role),
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, toUserResponse(user))
+}
+
+func (h *UserHandler) GetUsers(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionUserManage); err != nil {
+		return err
+	}
+
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+
+	users, total, err := h.userService.GetUsers(c.Request().Context(), page, limit)
+	if err != nil {
+		return err
+	}
+
+	userResponses := make([]dto.UserResponse, 0, len(users))
+	for i := range users {
+		userResponses = append(userResponses, toUserResponse(&users[i]))
+	}
+
+	response := dto.UserListResponse{
+		Users: userResponses,
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *UserHandler) GetUserByID(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionUserManage); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	user, err := h.userService.GetUserByID(c.Request().Context(), uint(id))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, toUserResponse(user))
+}
+
+func (h *UserHandler) DisableUser(c echo.Context) error {
+	return h.setDisabled(c, true)
+}
+
+func (h *UserHandler) EnableUser(c echo.Context) error {
+	return h.setDisabled(c, false)
+}
+
+func (h *UserHandler) setDisabled(c echo.Context, disabled bool) error {
+	if err := authorize(c, h.authorizer, domain.PermissionUserManage); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	user, err := h.userService.SetUserDisabled(c.Request().Context(), uint(id), disabled)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, toUserResponse(user))
+}
+
+func (h *UserHandler) UpdateUserRole(c echo.Context) error {
+	if err := authorize(c, h.authorizer, domain.PermissionUserManage); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	var req dto.UpdateUserRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	user, err := h.userService.SetUserRole(c.Request().Context(), uint(id), domain.Role(req.Role))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, toUserResponse(user))
+}
+
+func toUserResponse(user *domain.User) dto.UserResponse {
+	return dto.UserResponse{
+		ID:        user.ID,
+		Username:  user.Username,
+		Role:      string(user.Role),
+		Disabled:  user.Disabled,
+		CreatedAt: user.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: user.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"task-be/internal/domain"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// normalizer is implemented by requests that clean up their input, such as
+// trimming a title, before they are validated.
+type normalizer interface {
+	Normalize()
+}
+
+// RequestValidator checks request DTOs against their validate tags. Besides
+// the built-in rules it knows task_status and task_priority, which accept
+// the values of the domain enums.
+type RequestValidator struct {
+	validate *validator.Validate
+}
+
+func NewRequestValidator() *RequestValidator {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	validate.RegisterValidation("task_status", func(fl validator.FieldLevel) bool {
+		return new(domain.Task).IsValidStatus(domain.TaskStatus(fl.Field().String()))
+	})
+	validate.RegisterValidation("task_priority", func(fl validator.FieldLevel) bool {
+		return new(domain.Task).IsValidPriority(domain.TaskPriority(fl.Field().String()))
+	})
+	return &RequestValidator{validate: validate}
+}
+
+// Validate normalizes the request and checks it. Every failing field is
+// reported in a domain validation error, so the response lists them all.
+func (v *RequestValidator) Validate(i interface{}) error {
+	if n, ok := i.(normalizer); ok {
+		n.Normalize()
+	}
+
+	err := v.validate.Struct(i)
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+
+	fields := make([]domain.FieldError, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		fields = append(fields, toFieldError(fieldErr))
+	}
+	return domain.NewValidationError("Request validation failed", fields...)
+}
+
+func toFieldError(fieldErr validator.FieldError) domain.FieldError {
+	// The namespace starts with the name of the request type.
+	_, field, _ := strings.Cut(fieldErr.Namespace(), ".")
+	fe := domain.FieldError{Field: field}
+
+	isText := fieldErr.Kind() == reflect.String
+	switch fieldErr.Tag() {
+	case "required":
+		fe.Code, fe.Message = "required", "is required"
+	case "min":
+		if isText && fieldErr.Param() == "1" {
+			fe.Code, fe.Message = "empty", "must not be empty"
+		} else if isText {
+			fe.Code, fe.Message = "too_short", "must be at least "+fieldErr.Param()+" characters"
+		} else {
+			fe.Code, fe.Message = "too_small", "must be at least "+fieldErr.Param()
+		}
+	case "max":
+		if isText {
+			fe.Code, fe.Message = "too_long", "must be at most "+fieldErr.Param()+" characters"
+		} else {
+			fe.Code, fe.Message = "too_large", "must be at most "+fieldErr.Param()
+		}
+	case "oneof":
+		fe.Code, fe.Message = "invalid_value", "must be one of "+strings.ReplaceAll(fieldErr.Param(), " ", ", ")
+	case "hexcolor":
+		fe.Code, fe.Message = "invalid_color", "must be a hex color such as #d73a4a"
+	case "task_status":
+		fe.Code, fe.Message = "invalid_status", "must be one of TO_DO, IN_PROGRESS, DONE"
+	case "task_priority":
+		fe.Code, fe.Message = "invalid_priority", "must be one of LOW, MEDIUM, HIGH, URGENT"
+	default:
+		fe.Code, fe.Message = "invalid", "is invalid"
+	}
+	return fe
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"task-be/internal/domain"
+	"task-be/internal/interfaces/http/dto"
+)
+
+// fieldCodes returns the code of every field a validation error reports.
+func fieldCodes(t *testing.T, err error) map[string]string {
+	t.Helper()
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) || !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("Expected validation error, got %v", err)
+	}
+	codes := make(map[string]string, len(domainErr.Fields))
+	for _, field := range domainErr.Fields {
+		if field.Message == "" {
+			t.Errorf("Expected a message for field %s", field.Field)
+		}
+		codes[field.Field] = field.Code
+	}
+	return codes
+}
+
+// ruleRequest covers the rules no request DTO uses yet.
+type ruleRequest struct {
+	Mode  string `json:"mode" validate:"oneof=any all"`
+	Count int    `json:"count" validate:"min=1,max=10"`
+	Email string `json:"email" validate:"omitempty,email"`
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestRequestValidatorReportsEveryField(t *testing.T) {
+	v := NewRequestValidator()
+
+	err := v.Validate(&dto.CreateUserRequest{Username: "al", Password: strings.Repeat("x", 73)})
+	codes := fieldCodes(t, err)
+	expected := map[string]string{"username": "too_short", "password": "too_long"}
+	if len(codes) != len(expected) {
+		t.Errorf("Expected fields %v, got %v", expected, codes)
+	}
+	for field, code := range expected {
+		if codes[field] != code {
+			t.Errorf("Expected %s for %s, got %q", code, field, codes[field])
+		}
+	}
+
+	if err := v.Validate(&dto.CreateUserRequest{Username: "alice", Password: "long enough"}); err != nil {
+		t.Errorf("Expected valid request, got %v", err)
+	}
+}
+
+func TestRequestValidatorCodes(t *testing.T) {
+	v := NewRequestValidator()
+
+	tests := []struct {
+		name  string
+		req   any
+		field string
+		code  string
+	}{
+		{"required", &dto.CreateCommentRequest{}, "body", "required"},
+		{"too long text", &dto.CreateCommentRequest{Body: strings.Repeat("x", 10001)}, "body", "too_long"},
+		{"too short text", &dto.CreateProjectRequest{Key: "A", Name: "Ops"}, "key", "too_short"},
+		{"hex color", &dto.CreateLabelRequest{Name: "bug", Color: "red"}, "color", "invalid_color"},
+		{"task status", &dto.UpdateTaskRequest{Status: strPtr("BLOCKED")}, "status", "invalid_status"},
+		{"task priority", &dto.CreateTaskRequest{Title: "Task", Priority: "CRITICAL"}, "priority", "invalid_priority"},
+		{"empty title", &dto.UpdateTaskRequest{Title: strPtr("")}, "title", "empty"},
+		{"required number", &dto.AssignTaskRequest{}, "assignee_id", "required"},
+		{"one of", &ruleRequest{Mode: "some", Count: 1}, "mode", "invalid_value"},
+		{"number too small", &ruleRequest{Mode: "any"}, "count", "too_small"},
+		{"number too large", &ruleRequest{Mode: "any", Count: 11}, "count", "too_large"},
+		{"other rule", &ruleRequest{Mode: "any", Count: 1, Email: "alice"}, "email", "invalid"},
+	}
+	for _, tt := range tests {
+		codes := fieldCodes(t, v.Validate(tt.req))
+		if codes[tt.field] != tt.code {
+			t.Errorf("%s: expected %s for %s, got %v", tt.name, tt.code, tt.field, codes)
+		}
+	}
+}
+
+func TestRequestValidatorAcceptsDomainEnums(t *testing.T) {
+	v := NewRequestValidator()
+
+	for _, status := range []domain.TaskStatus{domain.StatusToDo, domain.StatusInProgress, domain.StatusDone} {
+		if err := v.Validate(&dto.UpdateTaskRequest{Status: strPtr(string(status))}); err != nil {
+			t.Errorf("Expected status %s to be valid, got %v", status, err)
+		}
+	}
+	for _, priority := range []domain.TaskPriority{domain.PriorityLow, domain.PriorityMedium, domain.PriorityHigh, domain.PriorityUrgent} {
+		if err := v.Validate(&dto.CreateTaskRequest{Title: "Task", Priority: string(priority)}); err != nil {
+			t.Errorf("Expected priority %s to be valid, got %v", priority, err)
+		}
+	}
+}
+
+func TestRequestValidatorNormalizesTitleFirst(t *testing.T) {
+	v := NewRequestValidator()
+
+	// A title of only whitespace is empty once trimmed.
+	if codes := fieldCodes(t, v.Validate(&dto.CreateTaskRequest{Title: "   "})); codes["title"] != "required" {
+		t.Errorf("Expected required for a blank title, got %v", codes)
+	}
+	if codes := fieldCodes(t, v.Validate(&dto.UpdateTaskRequest{Title: strPtr(" \t ")})); codes["title"] != "empty" {
+		t.Errorf("Expected empty for a blank title, got %v", codes)
+	}
+
+	// Surrounding whitespace does not count towards the maximum length.
+	req := &dto.CreateTaskRequest{Title: "  " + strings.Repeat("x", 255) + "  "}
+	if err := v.Validate(req); err != nil {
+		t.Errorf("Expected trimmed title to be valid, got %v", err)
+	}
+	if len(req.Title) != 255 {
+		t.Errorf("Expected the request to carry the trimmed title, got %d characters", len(req.Title))
+	}
+}
+
+func TestToUpdateTaskInputReportsInvalidDates(t *testing.T) {
+	_, err := toUpdateTaskInput(dto.UpdateTaskRequest{StartAt: strPtr("tomorrow"), DueAt: strPtr("2023-11-01")})
+	codes := fieldCodes(t, err)
+	if codes["start_at"] != "invalid_format" || codes["due_at"] != "invalid_format" {
+		t.Errorf("Expected invalid_format for start_at and due_at, got %v", codes)
+	}
+
+	input, err := toUpdateTaskInput(dto.UpdateTaskRequest{StartAt: strPtr(""), DueAt: strPtr("2023-11-01T17:00:00Z")})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !input.ClearStartAt || input.DueAt == nil {
+		t.Errorf("Expected start_at cleared and due_at set, got %+v", input)
+	}
+}
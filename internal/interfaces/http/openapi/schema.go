@@ -0,0 +1,173 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"task-be/internal/domain"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaRegistry derives component schemas from the request and response
+// DTOs. A struct becomes a named schema the first time it is referenced.
+type schemaRegistry struct {
+	schemas map[string]*Schema
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{schemas: map[string]*Schema{}}
+}
+
+// ref returns a reference to the component schema of a DTO, adding it if
+// needed.
+func (r *schemaRegistry) ref(v any) *Schema {
+	return r.schemaOf(reflect.TypeOf(v))
+}
+
+func (r *schemaRegistry) schemaOf(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType || t.Kind() == reflect.Interface:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := r.schemaOf(t.Elem())
+		if schema.Ref != "" || schema.Type == nil {
+			return schema
+		}
+		nullable := *schema
+		nullable.Type = []string{schema.Type.(string), "null"}
+		return &nullable
+	case reflect.Slice:
+		return &Schema{Type: "array", Items: r.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaOf(t.Elem())}
+	case reflect.Struct:
+		name := t.Name()
+		if _, ok := r.schemas[name]; !ok {
+			// Register the name first so recursive types terminate.
+			r.schemas[name] = &Schema{}
+			*r.schemas[name] = *r.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Minimum: intPtr(0)}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	default:
+		return &Schema{Type: "string"}
+	}
+}
+
+// structSchema describes a struct by its JSON fields. Embedded structs are
+// flattened as encoding/json does. A field of a request is required when it
+// is validated as required; a field of a response is required unless it is
+// omitted when empty.
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	isRequest := strings.HasSuffix(t.Name(), "Request")
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			embedded := r.structSchema(field.Type)
+			for propName, prop := range embedded.Properties {
+				schema.Properties[propName] = prop
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		rules := strings.Split(field.Tag.Get("validate"), ",")
+		prop := r.schemaOf(field.Type)
+		if prop.Ref == "" {
+			applyRules(prop, field.Type, rules)
+			if field.Type.Kind() == reflect.Pointer && len(prop.Enum) > 0 {
+				prop.Enum = append(prop.Enum, nil)
+			}
+		}
+		schema.Properties[name] = prop
+
+		omitEmpty := strings.Contains(options, "omitempty")
+		if (isRequest && hasRule(rules, "required")) || (!isRequest && !omitEmpty) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
+
+// applyRules translates validate tags into schema keywords.
+func applyRules(schema *Schema, t reflect.Type, rules []string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	isText := t.Kind() == reflect.String
+
+	for _, rule := range rules {
+		tag, param, _ := strings.Cut(rule, "=")
+		switch tag {
+		case "min", "max":
+			n, err := strconv.Atoi(param)
+			if err != nil {
+				continue
+			}
+			switch {
+			case isText && tag == "min":
+				schema.MinLength = intPtr(n)
+			case isText:
+				schema.MaxLength = intPtr(n)
+			case tag == "min":
+				schema.Minimum = intPtr(n)
+			default:
+				schema.Maximum = intPtr(n)
+			}
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				schema.Enum = append(schema.Enum, value)
+			}
+		case "hexcolor":
+			schema.Pattern = "^#([0-9a-fA-F]{3}|[0-9a-fA-F]{4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$"
+		case "task_status":
+			schema.Enum = []any{domain.StatusToDo, domain.StatusInProgress, domain.StatusDone}
+		case "task_priority":
+			schema.Enum = []any{domain.PriorityLow, domain.PriorityMedium, domain.PriorityHigh, domain.PriorityUrgent}
+		}
+	}
+}
+
+func hasRule(rules []string, name string) bool {
+	for _, rule := range rules {
+		if rule == name {
+			return true
+		}
+	}
+	return false
+}
+
+func intPtr(n int) *int {
+	return &n
+}
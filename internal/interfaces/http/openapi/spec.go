@@ -0,0 +1,455 @@
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"task-be/internal/interfaces/http/dto"
+)
+
+const (
+	mimeJSON        = "application/json"
+	mimeProblemJSON = "application/problem+json"
+	mimeMultipart   = "multipart/form-data"
+	mimeHTML        = "text/html"
+	mimeOctetStream = "application/octet-stream"
+)
+
+// access says who may call an operation.
+type access int
+
+const (
+	// authenticated operations need Basic credentials or a bearer token.
+	authenticated access = iota
+	// anonymousRead operations may also be called without credentials
+	// while AUTH_ALLOW_ANONYMOUS_READ is on.
+	anonymousRead
+	// public operations ignore credentials.
+	public
+)
+
+// route describes one operation of the router. Request and Response are
+// DTOs rendered as JSON, unless the content type says otherwise.
+type route struct {
+	Method      string
+	Path        string
+	OperationID string
+	Summary     string
+	Tag         string
+	Access      access
+	Params      []Parameter
+
+	Request     any
+	RequestType string
+
+	Status       int
+	Response     any
+	ResponseType string
+
+	// NotModified documents a 304 answer to If-None-Match.
+	NotModified bool
+	// Transition documents the 409 sent for a disallowed status change.
+	Transition bool
+}
+
+var pathParamPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// pathParams describes the path parameters shared by many routes. The id
+// parameter is described by the resource its path starts with.
+var pathParams = map[string]string{
+	"/tasks/":       "ID of the task",
+	"/projects/":    "ID of the project",
+	"/labels/":      "ID of the label",
+	"/admin/users/": "ID of the user account",
+	"blockerId":     "ID of the blocking task",
+	"labelId":       "ID of the label",
+	"commentId":     "ID of the comment",
+	"attachmentId":  "ID of the attachment",
+}
+
+// Spec returns the OpenAPI document of the API. The routes listed here must
+// match the ones registered by router.NewRouter, which a router test checks.
+func Spec() *Document {
+	schemas := newSchemaRegistry()
+	doc := &Document{
+		OpenAPI: "3.1.0",
+		Info: Info{
+			Title:       "Task Management API",
+			Version:     "1.0.0",
+			Description: "A RESTful API for managing tasks. Errors are answered with application/problem+json documents.",
+		},
+		Tags: []Tag{
+			{Name: "Auth", Description: "Access and refresh tokens"},
+			{Name: "Tasks", Description: "Tasks with their comments, attachments, labels and history"},
+			{Name: "Projects", Description: "Projects grouping tasks"},
+			{Name: "Labels", Description: "Labels attachable to tasks"},
+			{Name: "Users", Description: "User accounts, admin role only"},
+			{Name: "Documentation", Description: "This document and its viewer"},
+		},
+		Paths: map[string]PathItem{},
+		Components: Components{
+			Schemas: schemas.schemas,
+			Responses: map[string]*Response{
+				"Problem": {
+					Description: "The request failed",
+					Content:     map[string]MediaType{mimeProblemJSON: {Schema: schemas.ref(dto.ProblemResponse{})}},
+				},
+			},
+			SecuritySchemes: map[string]SecurityScheme{
+				"basicAuth": {Type: "http", Scheme: "basic", Description: "Username and password of a user account"},
+				"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT",
+					Description: "Access token issued by POST /auth/login or POST /auth/refresh"},
+			},
+		},
+		Security: []SecurityRequirement{{"basicAuth": {}}, {"bearerAuth": {}}},
+	}
+
+	for _, r := range routes() {
+		item, ok := doc.Paths[r.Path]
+		if !ok {
+			item = PathItem{}
+			doc.Paths[r.Path] = item
+		}
+		item[strings.ToLower(r.Method)] = r.operation(schemas)
+	}
+	return doc
+}
+
+func (r route) operation(schemas *schemaRegistry) *Operation {
+	op := &Operation{
+		Tags:        []string{r.Tag},
+		Summary:     r.Summary,
+		OperationID: r.OperationID,
+		Responses:   map[string]*Response{"default": {Ref: "#/components/responses/Problem"}},
+	}
+
+	for _, match := range pathParamPattern.FindAllStringSubmatch(r.Path, -1) {
+		name := match[1]
+		if r.hasParam(name) {
+			continue
+		}
+		description := pathParams[name]
+		if name == "id" {
+			prefix, _, _ := strings.Cut(r.Path, "{id}")
+			description = pathParams[prefix]
+		}
+		op.Parameters = append(op.Parameters, Parameter{Name: name, In: "path", Required: true,
+			Description: description, Schema: &Schema{Type: "integer", Minimum: intPtr(1)}})
+	}
+	op.Parameters = append(op.Parameters, r.Params...)
+	// The idempotency middleware covers every unsafe method under /tasks.
+	if strings.HasPrefix(r.Path, "/tasks") && r.Method != http.MethodGet {
+		op.Parameters = append(op.Parameters, idempotencyKeyParam)
+	}
+
+	switch r.Access {
+	case anonymousRead:
+		op.Security = &[]SecurityRequirement{{"basicAuth": {}}, {"bearerAuth": {}}, {}}
+	case public:
+		op.Security = &[]SecurityRequirement{}
+	}
+
+	if r.Request != nil {
+		contentType := r.RequestType
+		if contentType == "" {
+			contentType = mimeJSON
+		}
+		op.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{contentType: {Schema: bodySchema(schemas, r.Request)}}}
+	}
+
+	response := &Response{Description: http.StatusText(r.Status)}
+	if r.Response != nil {
+		contentType := r.ResponseType
+		if contentType == "" {
+			contentType = mimeJSON
+		}
+		response.Content = map[string]MediaType{contentType: {Schema: bodySchema(schemas, r.Response)}}
+	}
+	if _, ok := r.Response.(dto.TaskResponse); ok {
+		response.Headers = map[string]Header{"ETag": etagHeader}
+	}
+	op.Responses[strconv.Itoa(r.Status)] = response
+
+	if r.NotModified {
+		op.Responses[strconv.Itoa(http.StatusNotModified)] = &Response{
+			Description: "The task is still at the version given in If-None-Match",
+			Headers:     map[string]Header{"ETag": etagHeader},
+		}
+	}
+	if r.Transition {
+		op.Responses[strconv.Itoa(http.StatusConflict)] = &Response{
+			Description: "The status change is not allowed by the workflow",
+			Content:     map[string]MediaType{mimeProblemJSON: {Schema: schemas.ref(dto.TransitionProblemResponse{})}},
+		}
+	}
+	return op
+}
+
+// bodySchema returns the schema of a body given as a DTO or as a schema.
+func bodySchema(schemas *schemaRegistry, body any) *Schema {
+	if schema, ok := body.(*Schema); ok {
+		return schema
+	}
+	return schemas.ref(body)
+}
+
+func (r route) hasParam(name string) bool {
+	for _, param := range r.Params {
+		if param.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	etagHeader = Header{Description: "Version of the task, usable with If-Match and If-None-Match", Schema: &Schema{Type: "string"}}
+
+	idempotencyKeyParam = header("Idempotency-Key", "Makes the request safe to retry; a retry with the same key replays the first response")
+	ifMatchParam        = header("If-Match", "ETag of the task; the request fails with 412 once the task has changed")
+	forceParam          = query("force", "Override the subtask and dependency rules of status changes", "boolean")
+)
+
+func query(name, description, schemaType string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: schemaType}}
+}
+
+func header(name, description string) Parameter {
+	return Parameter{Name: name, In: "header", Description: description, Schema: &Schema{Type: "string"}}
+}
+
+func pageParams() []Parameter {
+	return []Parameter{
+		query("page", "Page number, starting at 1", "integer"),
+		query("limit", "Page size", "integer"),
+	}
+}
+
+// taskFilterParams are the filters shared by every task list.
+func taskFilterParams() []Parameter {
+	return []Parameter{
+		query("status", "Comma-separated statuses", "string"),
+		query("priority", "Comma-separated priorities", "string"),
+		dateParam("due_before"),
+		dateParam("due_after"),
+		dateParam("created_before"),
+		dateParam("created_after"),
+		dateParam("updated_before"),
+		dateParam("updated_after"),
+		query("title_contains", "Case-insensitive substring of the title", "string"),
+		query("overdue", "Only tasks past their due date", "boolean"),
+		query("deleted", "List the trash instead, admin role only", "boolean"),
+		query("assignee", "User ID of the assignee, or me", "string"),
+		query("created_by", "User ID of the creator", "integer"),
+		query("project_id", "Project ID", "integer"),
+		query("parent_id", "ID of the parent task", "integer"),
+		query("labels", "Comma-separated label IDs", "string"),
+		{Name: "label_mode", In: "query", Description: "Whether tasks need any or all of the labels",
+			Schema: &Schema{Type: "string", Enum: []any{"any", "all"}}},
+	}
+}
+
+// taskPageParams select a page of a task list, by page number unless a
+// cursor is given or cursor pagination is asked for.
+func taskPageParams() []Parameter {
+	return []Parameter{
+		query("page", "Page number, starting at 1", "integer"),
+		query("limit", "Page size", "integer"),
+		{Name: "paginate", In: "query", Description: "cursor starts cursor pagination; a cursor implies it",
+			Schema: &Schema{Type: "string", Enum: []any{"page", "cursor"}}},
+		query("cursor", "next_cursor or prev_cursor of a previous page; switches to cursor pagination", "string"),
+		query("include_total", "Count the matching tasks with cursor pagination", "boolean"),
+	}
+}
+
+// taskListParams are the parameters of the filtered task lists.
+func taskListParams() []Parameter {
+	params := append(taskFilterParams(), query("sort", "Comma-separated fields, prefixed with - for descending order", "string"))
+	return append(params, taskPageParams()...)
+}
+
+func dateParam(name string) Parameter {
+	return Parameter{Name: name, In: "query", Description: "RFC3339 timestamp", Schema: &Schema{Type: "string", Format: "date-time"}}
+}
+
+func routes() []route {
+	binary := &Schema{Type: "string", Format: "binary"}
+
+	return []route{
+		{Method: http.MethodPost, Path: "/auth/login", OperationID: "login", Tag: "Auth", Access: public,
+			Summary: "Exchange a username and password for an access token and a refresh token",
+			Request: dto.LoginRequest{}, Status: http.StatusOK, Response: dto.TokenResponse{}},
+		{Method: http.MethodPost, Path: "/auth/refresh", OperationID: "refreshToken", Tag: "Auth", Access: public,
+			Summary: "Exchange a refresh token for a new token pair",
+			Request: dto.RefreshTokenRequest{}, Status: http.StatusOK, Response: dto.TokenResponse{}},
+		{Method: http.MethodPost, Path: "/auth/logout", OperationID: "logout", Tag: "Auth", Access: public,
+			Summary: "Revoke a refresh token and its session",
+			Request: dto.RefreshTokenRequest{}, Status: http.StatusNoContent},
+
+		{Method: http.MethodGet, Path: "/tasks", OperationID: "getTasks", Tag: "Tasks", Access: anonymousRead,
+			Summary: "Get all tasks with pagination and filtering",
+			Params:  taskListParams(), Status: http.StatusOK, Response: dto.TaskListResponse{}},
+		{Method: http.MethodGet, Path: "/tasks/search", OperationID: "searchTasks", Tag: "Tasks", Access: anonymousRead,
+			Summary: "Full-text search over titles and descriptions",
+			Params: append(append([]Parameter{{Name: "q", In: "query", Required: true, Description: "Search query", Schema: &Schema{Type: "string"}}},
+				taskFilterParams()...), pageParams()...),
+			Status: http.StatusOK, Response: dto.TaskSearchResponse{}},
+		{Method: http.MethodPost, Path: "/tasks", OperationID: "createTask", Tag: "Tasks",
+			Summary: "Create a new task",
+			Request: dto.CreateTaskRequest{}, Status: http.StatusCreated, Response: dto.TaskResponse{}},
+		{Method: http.MethodPatch, Path: "/tasks", OperationID: "updateTasks", Tag: "Tasks",
+			Summary: "Apply the same update, e.g. a status change, to several tasks",
+			Params: []Parameter{
+				{Name: "ids", In: "query", Required: true, Description: "Comma-separated task IDs", Schema: &Schema{Type: "string"}},
+				query("atomic", "Apply all updates or none, true by default", "boolean"),
+				forceParam,
+			},
+			Request: dto.UpdateTaskRequest{}, Status: http.StatusOK, Response: dto.BulkTaskResponse{}},
+		{Method: http.MethodPost, Path: "/tasks/bulk", OperationID: "bulkTasks", Tag: "Tasks",
+			Summary: "Create, update and delete tasks in one transaction (deletes need admin)",
+			Params:  []Parameter{forceParam},
+			Request: dto.BulkTaskRequest{}, Status: http.StatusOK, Response: dto.BulkTaskResponse{}},
+		{Method: http.MethodGet, Path: "/tasks/{id}", OperationID: "getTask", Tag: "Tasks", Access: anonymousRead,
+			Summary: "Get a specific task by ID or key (OPS-42)",
+			Params: []Parameter{
+				{Name: "id", In: "path", Required: true, Description: "Task ID or key", Schema: &Schema{Type: "string"}},
+				header("If-None-Match", "ETag of a cached copy of the task"),
+			},
+			Status: http.StatusOK, Response: dto.TaskResponse{}, NotModified: true},
+		{Method: http.MethodPatch, Path: "/tasks/{id}", OperationID: "updateTask", Tag: "Tasks",
+			Summary: "Update an existing task",
+			Params:  []Parameter{ifMatchParam, forceParam},
+			Request: dto.UpdateTaskRequest{}, Status: http.StatusOK, Response: dto.TaskResponse{}, Transition: true},
+		{Method: http.MethodDelete, Path: "/tasks/{id}", OperationID: "deleteTask", Tag: "Tasks",
+			Summary: "Move a task to the trash, or delete it for good",
+			Params:  []Parameter{ifMatchParam, query("permanent", "Delete the task for good", "boolean")},
+			Status:  http.StatusNoContent},
+		{Method: http.MethodGet, Path: "/tasks/{id}/transitions", OperationID: "getTaskTransitions", Tag: "Tasks", Access: anonymousRead,
+			Summary: "List the statuses a task can move to next",
+			Status:  http.StatusOK, Response: dto.TaskTransitionsResponse{}},
+		{Method: http.MethodGet, Path: "/tasks/{id}/children", OperationID: "getTaskChildren", Tag: "Tasks", Access: anonymousRead,
+			Summary: "List the subtasks of a task",
+			Params:  taskPageParams(),
+			Status:  http.StatusOK, Response: dto.TaskListResponse{}},
+		{Method: http.MethodGet, Path: "/tasks/{id}/dependencies", OperationID: "getTaskDependencies", Tag: "Tasks", Access: anonymousRead,
+			Summary: "List the tasks blocking and blocked by a task",
+			Status:  http.StatusOK, Response: dto.TaskDependenciesResponse{}},
+		{Method: http.MethodPost, Path: "/tasks/{id}/dependencies", OperationID: "addTaskDependency", Tag: "Tasks",
+			Summary: "Record that another task blocks this one",
+			Request: dto.AddTaskDependencyRequest{}, Status: http.StatusOK, Response: dto.TaskResponse{}},
+		{Method: http.MethodDelete, Path: "/tasks/{id}/dependencies/{blockerId}", OperationID: "removeTaskDependency", Tag: "Tasks",
+			Summary: "Remove a blocking task",
+			Status:  http.StatusOK, Response: dto.TaskResponse{}},
+		{Method: http.MethodPost, Path: "/tasks/{id}/assign", OperationID: "assignTask", Tag: "Tasks",
+			Summary: "Assign a task to a user",
+			Request: dto.AssignTaskRequest{}, Status: http.StatusOK, Response: dto.TaskResponse{}},
+		{Method: http.MethodPost, Path: "/tasks/{id}/unassign", OperationID: "unassignTask", Tag: "Tasks",
+			Summary: "Remove the assignee of a task",
+			Status:  http.StatusOK, Response: dto.TaskResponse{}},
+		{Method: http.MethodPost, Path: "/tasks/{id}/labels/{labelId}", OperationID: "addTaskLabel", Tag: "Tasks",
+			Summary: "Attach a label to a task",
+			Status:  http.StatusOK, Response: dto.TaskResponse{}},
+		{Method: http.MethodDelete, Path: "/tasks/{id}/labels/{labelId}", OperationID: "removeTaskLabel", Tag: "Tasks",
+			Summary: "Detach a label from a task",
+			Status:  http.StatusOK, Response: dto.TaskResponse{}},
+		{Method: http.MethodGet, Path: "/tasks/{id}/comments", OperationID: "getComments", Tag: "Tasks", Access: anonymousRead,
+			Summary: "List the comments of a task, oldest first",
+			Params:  pageParams(), Status: http.StatusOK, Response: dto.CommentListResponse{}},
+		{Method: http.MethodPost, Path: "/tasks/{id}/comments", OperationID: "createComment", Tag: "Tasks",
+			Summary: "Comment on a task",
+			Request: dto.CreateCommentRequest{}, Status: http.StatusCreated, Response: dto.CommentResponse{}},
+		{Method: http.MethodPatch, Path: "/tasks/{id}/comments/{commentId}", OperationID: "updateComment", Tag: "Tasks",
+			Summary: "Edit a comment (author or admin)",
+			Request: dto.UpdateCommentRequest{}, Status: http.StatusOK, Response: dto.CommentResponse{}},
+		{Method: http.MethodDelete, Path: "/tasks/{id}/comments/{commentId}", OperationID: "deleteComment", Tag: "Tasks",
+			Summary: "Delete a comment (author or admin)",
+			Status:  http.StatusNoContent},
+		{Method: http.MethodGet, Path: "/tasks/{id}/attachments", OperationID: "getAttachments", Tag: "Tasks", Access: anonymousRead,
+			Summary: "List the attachments of a task",
+			Status:  http.StatusOK, Response: dto.AttachmentListResponse{}},
+		{Method: http.MethodPost, Path: "/tasks/{id}/attachments", OperationID: "uploadAttachment", Tag: "Tasks",
+			Summary:     "Upload an attachment",
+			Request:     &Schema{Type: "object", Required: []string{"file"}, Properties: map[string]*Schema{"file": binary}},
+			RequestType: mimeMultipart, Status: http.StatusCreated, Response: dto.AttachmentResponse{}},
+		{Method: http.MethodGet, Path: "/tasks/{id}/attachments/{attachmentId}", OperationID: "downloadAttachment", Tag: "Tasks", Access: anonymousRead,
+			Summary: "Download an attachment",
+			Status:  http.StatusOK, Response: binary, ResponseType: mimeOctetStream},
+		{Method: http.MethodDelete, Path: "/tasks/{id}/attachments/{attachmentId}", OperationID: "deleteAttachment", Tag: "Tasks",
+			Summary: "Delete an attachment",
+			Status:  http.StatusNoContent},
+		{Method: http.MethodPost, Path: "/tasks/{id}/restore", OperationID: "restoreTask", Tag: "Tasks",
+			Summary: "Restore a task from the trash",
+			Status:  http.StatusOK, Response: dto.TaskResponse{}},
+		{Method: http.MethodGet, Path: "/tasks/{id}/history", OperationID: "getTaskHistory", Tag: "Tasks",
+			Summary: "Get the change history of a task",
+			Params:  pageParams(), Status: http.StatusOK, Response: dto.TaskHistoryResponse{}},
+
+		{Method: http.MethodGet, Path: "/projects", OperationID: "getProjects", Tag: "Projects", Access: anonymousRead,
+			Summary: "List projects",
+			Params:  append(pageParams(), query("archived", "Include archived projects", "boolean")),
+			Status:  http.StatusOK, Response: dto.ProjectListResponse{}},
+		{Method: http.MethodPost, Path: "/projects", OperationID: "createProject", Tag: "Projects",
+			Summary: "Create a project",
+			Request: dto.CreateProjectRequest{}, Status: http.StatusCreated, Response: dto.ProjectResponse{}},
+		{Method: http.MethodGet, Path: "/projects/{id}", OperationID: "getProject", Tag: "Projects", Access: anonymousRead,
+			Summary: "Get a specific project",
+			Status:  http.StatusOK, Response: dto.ProjectResponse{}},
+		{Method: http.MethodPatch, Path: "/projects/{id}", OperationID: "updateProject", Tag: "Projects",
+			Summary: "Rename, describe or archive a project",
+			Request: dto.UpdateProjectRequest{}, Status: http.StatusOK, Response: dto.ProjectResponse{}},
+		{Method: http.MethodDelete, Path: "/projects/{id}", OperationID: "deleteProject", Tag: "Projects",
+			Summary: "Delete a project without tasks",
+			Status:  http.StatusNoContent},
+		{Method: http.MethodGet, Path: "/projects/{id}/tasks", OperationID: "getProjectTasks", Tag: "Projects", Access: anonymousRead,
+			Summary: "List the tasks of a project with the same filters as GET /tasks",
+			Params:  taskListParams(), Status: http.StatusOK, Response: dto.TaskListResponse{}},
+
+		{Method: http.MethodGet, Path: "/labels", OperationID: "getLabels", Tag: "Labels", Access: anonymousRead,
+			Summary: "List all labels",
+			Status:  http.StatusOK, Response: dto.LabelListResponse{}},
+		{Method: http.MethodPost, Path: "/labels", OperationID: "createLabel", Tag: "Labels",
+			Summary: "Create a label",
+			Request: dto.CreateLabelRequest{}, Status: http.StatusCreated, Response: dto.LabelResponse{}},
+		{Method: http.MethodGet, Path: "/labels/{id}", OperationID: "getLabel", Tag: "Labels", Access: anonymousRead,
+			Summary: "Get a specific label",
+			Status:  http.StatusOK, Response: dto.LabelResponse{}},
+		{Method: http.MethodPatch, Path: "/labels/{id}", OperationID: "updateLabel", Tag: "Labels",
+			Summary: "Rename or recolor a label",
+			Request: dto.UpdateLabelRequest{}, Status: http.StatusOK, Response: dto.LabelResponse{}},
+		{Method: http.MethodDelete, Path: "/labels/{id}", OperationID: "deleteLabel", Tag: "Labels",
+			Summary: "Delete a label and detach it from all tasks",
+			Status:  http.StatusNoContent},
+
+		{Method: http.MethodGet, Path: "/admin/users", OperationID: "getUsers", Tag: "Users",
+			Summary: "List user accounts",
+			Params:  pageParams(), Status: http.StatusOK, Response: dto.UserListResponse{}},
+		{Method: http.MethodPost, Path: "/admin/users", OperationID: "createUser", Tag: "Users",
+			Summary: "Create a user account",
+			Request: dto.CreateUserRequest{}, Status: http.StatusCreated, Response: dto.UserResponse{}},
+		{Method: http.MethodGet, Path: "/admin/users/{id}", OperationID: "getUser", Tag: "Users",
+			Summary: "Get a user account",
+			Status:  http.StatusOK, Response: dto.UserResponse{}},
+		{Method: http.MethodPost, Path: "/admin/users/{id}/disable", OperationID: "disableUser", Tag: "Users",
+			Summary: "Disable a user account",
+			Status:  http.StatusOK, Response: dto.UserResponse{}},
+		{Method: http.MethodPost, Path: "/admin/users/{id}/enable", OperationID: "enableUser", Tag: "Users",
+			Summary: "Re-enable a user account",
+			Status:  http.StatusOK, Response: dto.UserResponse{}},
+		{Method: http.MethodPut, Path: "/admin/users/{id}/role", OperationID: "updateUserRole", Tag: "Users",
+			Summary: "Change the role of a user account",
+			Request: dto.UpdateUserRoleRequest{}, Status: http.StatusOK, Response: dto.UserResponse{}},
+
+		{Method: http.MethodGet, Path: "/openapi.json", OperationID: "getOpenAPISpec", Tag: "Documentation", Access: public,
+			Summary: "Get this OpenAPI document",
+			Status:  http.StatusOK, Response: &Schema{Type: "object"}},
+		{Method: http.MethodGet, Path: "/docs", OperationID: "getDocs", Tag: "Documentation", Access: public,
+			Summary: "Browse the API documentation with Swagger UI",
+			Status:  http.StatusOK, Response: &Schema{Type: "string"}, ResponseType: mimeHTML},
+		{Method: http.MethodGet, Path: "/docs/{file}", OperationID: "getDocsAsset", Tag: "Documentation", Access: public,
+			Summary: "Get a Swagger UI asset",
+			Params:  []Parameter{{Name: "file", In: "path", Required: true, Description: "Name of the asset", Schema: &Schema{Type: "string"}}},
+			Status:  http.StatusOK, Response: &Schema{Type: "string", Format: "binary"}, ResponseType: mimeOctetStream},
+	}
+}
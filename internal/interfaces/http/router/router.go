@@ -1,29 +1,102 @@
 package router
 
 import (
-	"github.com/labstack/echo/v4"
-	echoMiddleware "github.com/labstack/echo/v4/middleware"
+	"task-be/internal/domain"
 	authMiddleware "task-be/internal/infrastructure/middleware"
-	"task-be/internal/infrastructure/config"
 	"task-be/internal/interfaces/http/handler"
+
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
 )
 
-func NewRouter(taskHandler *handler.TaskHandler, cfg *config.Config) *echo.Echo {
+func NewRouter(taskHandler *handler.TaskHandler, projectHandler *handler.ProjectHandler, commentHandler *handler.CommentHandler, attachmentHandler *handler.AttachmentHandler, labelHandler *handler.LabelHandler, userHandler *handler.UserHandler, authHandler *handler.AuthHandler, docsHandler *handler.DocsHandler, userService domain.UserService, authService domain.AuthService, idempotencyService domain.IdempotencyService) *echo.Echo {
 	e := echo.New()
+	// Errors are answered as application/problem+json. The request ID set
+	// first is echoed in the problem and the access log.
+	e.HTTPErrorHandler = handler.HTTPErrorHandler
+	e.Validator = handler.NewRequestValidator()
 
+	e.Use(echoMiddleware.RequestID())
 	e.Use(echoMiddleware.Logger())
 	e.Use(echoMiddleware.Recover())
-	e.Use(echoMiddleware.CORS())
+	// Browsers only let scripts read the ETag needed for If-Match, whether a
+	// response was replayed, and the request ID when they are exposed
+	// explicitly.
+	e.Use(echoMiddleware.CORSWithConfig(echoMiddleware.CORSConfig{
+		ExposeHeaders: []string{"ETag", "Idempotent-Replayed", echo.HeaderXRequestID},
+	}))
 
+	authenticate := authMiddleware.Authenticate(userService, authService)
+
+	auth := e.Group("/auth")
+	auth.POST("/login", authHandler.Login)
+	auth.POST("/refresh", authHandler.Refresh)
+	auth.POST("/logout", authHandler.Logout)
+
+	// Permission checks live in the handlers; the middleware only resolves
+	// the caller when credentials are present. Task mutations sent with an
+	// Idempotency-Key can be retried safely; attachment uploads are the
+	// largest requests they carry.
 	tasks := e.Group("/tasks")
+	tasks.Use(authenticate, authMiddleware.Idempotency(idempotencyService, attachmentHandler.MaxRequestSize()))
 	tasks.GET("", taskHandler.GetTasks)
+	tasks.GET("/search", taskHandler.SearchTasks)
+	tasks.POST("", taskHandler.CreateTask)
+	tasks.PATCH("", taskHandler.UpdateTasks)
+	tasks.POST("/bulk", taskHandler.BulkTasks)
 	tasks.GET("/:id", taskHandler.GetTaskByID)
+	tasks.PATCH("/:id", taskHandler.UpdateTask)
+	tasks.DELETE("/:id", taskHandler.DeleteTask)
+	tasks.GET("/:id/transitions", taskHandler.GetTaskTransitions)
+	tasks.GET("/:id/children", taskHandler.GetTaskChildren)
+	tasks.GET("/:id/dependencies", taskHandler.GetTaskDependencies)
+	tasks.POST("/:id/dependencies", taskHandler.AddTaskDependency)
+	tasks.DELETE("/:id/dependencies/:blockerId", taskHandler.RemoveTaskDependency)
+	tasks.POST("/:id/assign", taskHandler.AssignTask)
+	tasks.POST("/:id/unassign", taskHandler.UnassignTask)
+	tasks.POST("/:id/labels/:labelId", taskHandler.AddTaskLabel)
+	tasks.DELETE("/:id/labels/:labelId", taskHandler.RemoveTaskLabel)
+	tasks.GET("/:id/comments", commentHandler.GetComments)
+	tasks.POST("/:id/comments", commentHandler.CreateComment)
+	tasks.PATCH("/:id/comments/:commentId", commentHandler.UpdateComment)
+	tasks.DELETE("/:id/comments/:commentId", commentHandler.DeleteComment)
+	tasks.GET("/:id/attachments", attachmentHandler.GetAttachments)
+	tasks.POST("/:id/attachments", attachmentHandler.UploadAttachment)
+	tasks.GET("/:id/attachments/:attachmentId", attachmentHandler.DownloadAttachment)
+	tasks.DELETE("/:id/attachments/:attachmentId", attachmentHandler.DeleteAttachment)
+	tasks.POST("/:id/restore", taskHandler.RestoreTask)
+	tasks.GET("/:id/history", taskHandler.GetTaskHistory)
+
+	projects := e.Group("/projects")
+	projects.Use(authenticate)
+	projects.GET("", projectHandler.GetProjects)
+	projects.POST("", projectHandler.CreateProject)
+	projects.GET("/:id", projectHandler.GetProjectByID)
+	projects.PATCH("/:id", projectHandler.UpdateProject)
+	projects.DELETE("/:id", projectHandler.DeleteProject)
+	projects.GET("/:id/tasks", projectHandler.GetProjectTasks)
+
+	labels := e.Group("/labels")
+	labels.Use(authenticate)
+	labels.GET("", labelHandler.GetLabels)
+	labels.POST("", labelHandler.CreateLabel)
+	labels.GET("/:id", labelHandler.GetLabelByID)
+	labels.PATCH("/:id", labelHandler.UpdateLabel)
+	labels.DELETE("/:id", labelHandler.DeleteLabel)
+
+	adminUsers := e.Group("/admin/users")
+	adminUsers.Use(authenticate)
+	adminUsers.GET("", userHandler.GetUsers)
+	adminUsers.POST("", userHandler.CreateUser)
+	adminUsers.GET("/:id", userHandler.GetUserByID)
+	adminUsers.POST("/:id/disable", userHandler.DisableUser)
+	adminUsers.POST("/:id/enable", userHandler.EnableUser)
+	adminUsers.PUT("/:id/role", userHandler.UpdateUserRole)
 
-	authTasks := e.Group("/tasks")
-	authTasks.Use(authMiddleware.BasicAuth(cfg))
-	authTasks.POST("", taskHandler.CreateTask)
-	authTasks.PATCH("/:id", taskHandler.UpdateTask)
-	authTasks.DELETE("/:id", taskHandler.DeleteTask)
+	// The API description and its viewer are public.
+	e.GET("/openapi.json", docsHandler.GetOpenAPISpec)
+	e.GET("/docs", docsHandler.GetDocs)
+	e.GET("/docs/:file", docsHandler.GetDocsAsset)
 
 	return e
 }
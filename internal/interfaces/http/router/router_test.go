@@ -0,0 +1,64 @@
+package router
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"task-be/internal/interfaces/http/handler"
+	"task-be/internal/interfaces/http/openapi"
+
+	"github.com/labstack/echo/v4"
+)
+
+var echoParamPattern = regexp.MustCompile(`:(\w+)`)
+
+// TestRoutesMatchOpenAPISpec fails when a route is registered without being
+// documented in the OpenAPI document, or documented without being registered.
+func TestRoutesMatchOpenAPISpec(t *testing.T) {
+	docsHandler, err := handler.NewDocsHandler(openapi.Spec())
+	if err != nil {
+		t.Fatalf("Expected spec to render, got %v", err)
+	}
+	e := NewRouter(&handler.TaskHandler{}, &handler.ProjectHandler{}, &handler.CommentHandler{}, &handler.AttachmentHandler{},
+		&handler.LabelHandler{}, &handler.UserHandler{}, &handler.AuthHandler{}, docsHandler, nil, nil, nil)
+
+	registered := map[string]bool{}
+	for _, route := range e.Routes() {
+		// Groups register catch-all routes that answer 404; they are not
+		// part of the API.
+		if route.Method == echo.RouteNotFound {
+			continue
+		}
+		path := echoParamPattern.ReplaceAllString(route.Path, "{$1}")
+		registered[route.Method+" "+path] = true
+	}
+
+	documented := map[string]bool{}
+	for path, item := range openapi.Spec().Paths {
+		for method := range item {
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	for _, route := range sortedKeys(registered) {
+		if !documented[route] {
+			t.Errorf("Expected route %s to be documented in the OpenAPI spec", route)
+		}
+	}
+	for _, route := range sortedKeys(documented) {
+		if !registered[route] {
+			t.Errorf("Expected documented route %s to be registered", route)
+		}
+	}
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}